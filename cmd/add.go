@@ -2,9 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 
-	"github.com/jeffrydegrande/claude-hoist/hoist"
 	"github.com/spf13/cobra"
 )
 
@@ -12,36 +13,41 @@ var addCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add all project permissions to your user config",
 	Run: func(cmd *cobra.Command, args []string) {
-		_, user, userPath, newAllow, newDeny, err := hoist.LoadBoth()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			os.Exit(1)
-		}
+		c, plan, extra := loadPlan(cmd)
+		printBlocked(os.Stdout, plan.Blocked)
+		newAllow := plan.Allowed()
 
-		if len(newAllow) == 0 && len(newDeny) == 0 {
+		if plan.Empty() {
 			fmt.Println("nothing to do — all project permissions already exist in user config")
 			return
 		}
 
-		if len(newAllow) > 0 {
-			fmt.Printf("New allow rules (%d):\n", len(newAllow))
-			for _, rule := range newAllow {
-				fmt.Printf("  + %s\n", rule)
+		printRules(newAllow, plan.Ask, plan.Deny)
+		extra.print(plan, newAllow)
+
+		yes, _ := cmd.Flags().GetBool("yes")
+		if m := plan.Mode; m != nil && m.Conflict() {
+			if yes {
+				fmt.Printf("keeping your %s %q; run without -y to replace it\n", m.Key, m.From)
+			} else {
+				fmt.Printf("\nReplace %s %q with %q? [y/N] ", m.Key, m.From, m.To)
+				var answer string
+				fmt.Scanln(&answer)
+				plan.ReplaceMode = answer == "y" || answer == "Y"
 			}
 		}
-		if len(newDeny) > 0 {
-			if len(newAllow) > 0 {
-				fmt.Println()
-			}
-			fmt.Printf("New deny rules (%d):\n", len(newDeny))
-			for _, rule := range newDeny {
-				fmt.Printf("  + %s\n", rule)
+		if yes {
+			for _, c := range plan.Env {
+				if c.Conflict {
+					fmt.Println("keeping your values for conflicting env variables; run without -y to choose")
+					break
+				}
 			}
 		}
+		extra.resolveEnv(plan, !yes)
 
-		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
-			fmt.Printf("\nMerge into %s? [y/N] ", userPath)
+			fmt.Printf("\nMerge into %s? [y/N] ", plan.UserPath)
 			var answer string
 			fmt.Scanln(&answer)
 			if answer != "y" && answer != "Y" {
@@ -50,17 +56,55 @@ var addCmd = &cobra.Command{
 			}
 		}
 
-		merged := hoist.Merge(user, newAllow, newDeny)
-		if err := hoist.WriteSettings(userPath, merged); err != nil {
-			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
-			os.Exit(1)
-		}
+		writePlan(c, plan)
 
-		fmt.Printf("done — wrote %s\n", userPath)
+		fmt.Printf("done — wrote %s\n", plan.UserPath)
 	},
 }
 
+// printRules lists the new allow, ask and deny rules.
+func printRules(allow, ask, deny []string) {
+	first := true
+	for _, l := range []struct {
+		name  string
+		rules []string
+	}{{"allow", allow}, {"ask", ask}, {"deny", deny}} {
+		if len(l.rules) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Println()
+		}
+		first = false
+		fmt.Printf("New %s rules (%d):\n", l.name, len(l.rules))
+		for _, rule := range l.rules {
+			fmt.Printf("  + %s\n", rule)
+		}
+	}
+}
+
+// printBlocked reports candidates skipped because managed settings deny them.
+// printBlocked lists the allow rules a managed deny rule forbids to w.
+func printBlocked(w io.Writer, blocked map[string]string) {
+	if len(blocked) == 0 {
+		return
+	}
+	rules := make([]string, 0, len(blocked))
+	for rule := range blocked {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	fmt.Fprintf(w, "Skipping %d rule(s) denied by managed settings:\n", len(rules))
+	for _, rule := range rules {
+		fmt.Fprintf(w, "  ! %s  (managed deny %s)\n", rule, blocked[rule])
+	}
+	fmt.Fprintln(w)
+}
+
 func init() {
+	addFilterFlags(addCmd)
 	addCmd.Flags().BoolP("yes", "y", false, "skip confirmation prompt")
+	addIncludeFlags(addCmd)
 	rootCmd.AddCommand(addCmd)
 }
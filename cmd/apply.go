@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <patch|plan>",
+	Short: "Apply a diff, JSON Patch or plan saved from diff or plan",
+	Long: `Applies a change saved with diff or plan to a settings file, so it can be
+reviewed or sent to someone first:
+
+  claude-hoist diff > hoist.diff
+  claude-hoist diff --format jsonpatch > hoist.json
+  claude-hoist plan -o hoist.plan
+  claude-hoist apply hoist.diff
+
+Unified diffs apply only when every hunk's context still matches the file
+exactly; JSON Patches only when every "test" operation passes. Otherwise
+nothing is written and the hunks or operations that don't match are listed.
+A plan file applies only when neither the project nor the user settings
+file it was computed from has changed since, and always to the file it was
+made for. The file is replaced atomically and must still be valid settings.
+
+The patch is applied to your user config unless --target names another
+file: a path, or one of the edit targets (project, project-shared, user,
+user-shared). Use "-" to read the patch from stdin.
+
+Env values that look like secrets are masked in diff output; save the patch
+with --reveal for it to apply. A patch that would write masked values is
+refused.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePatchFiles,
+	Run: func(cmd *cobra.Command, args []string) {
+		var patch []byte
+		var err error
+		if args[0] == "-" {
+			patch, err = io.ReadAll(os.Stdin)
+		} else {
+			patch, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		c := newClient()
+		target, _ := cmd.Flags().GetString("target")
+		var plan *hoist.PlanFile
+		path := applyTargetPath(c, target)
+		if hoist.IsPlanFile(patch) {
+			if target != "" {
+				fmt.Fprintln(os.Stderr, "error: a plan file records its target; --target can't be used with it")
+				os.Exit(1)
+			}
+			if plan, err = hoist.ParsePlanFile(patch); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			path = plan.Target.Path
+		}
+
+		// Hold the lock from reading the file to writing it, so the patch
+		// isn't checked against content another writer is replacing.
+		unlock, err := c.Lock(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		defer unlock()
+		fail := func() {
+			unlock() // os.Exit skips deferred calls
+			os.Exit(1)
+		}
+
+		var data, out []byte
+		var n int
+		if plan != nil {
+			data, out, n, err = applyPlan(c, plan)
+		} else {
+			data, err = c.FS().ReadFile(path)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				fail()
+			}
+			out, n, err = applyPatch(patch, data)
+		}
+		if err != nil {
+			var rej *hoist.RejectError
+			if errors.As(err, &rej) {
+				fmt.Fprintf(os.Stderr, "error: %s: %v\n", path, err)
+				for _, r := range rej.Rejects {
+					fmt.Fprintf(os.Stderr, "  %s\n", r)
+				}
+				fail()
+			}
+			if errors.Is(err, hoist.ErrStalePlan) {
+				fmt.Fprintf(os.Stderr, "error: %v; run plan again\n", err)
+				fail()
+			}
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fail()
+		}
+
+		if keys := hoist.MaskedEnv(data, out); len(keys) > 0 {
+			fmt.Fprintf(os.Stderr, "error: the patch sets %s to a masked value; save it with diff --reveal\n", strings.Join(keys, ", "))
+			fail()
+		}
+		if reportIssues(os.Stderr, path, out) {
+			fmt.Fprintf(os.Stderr, "error: %s would not be valid after the patch; nothing written\n", path)
+			fail()
+		}
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			fmt.Printf("patch applies cleanly to %s\n", path)
+			return
+		}
+		if bytes.Equal(out, data) {
+			fmt.Printf("%s already matches the patch\n", path)
+			return
+		}
+		if err := c.FS().WriteFile(path, out, 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			fail()
+		}
+		fmt.Printf("applied %d change(s) to %s\n", n, path)
+	},
+}
+
+// applyPatch applies a unified diff or JSON Patch to data and returns the
+// result along with the number of hunks or operations.
+func applyPatch(patch, data []byte) ([]byte, int, error) {
+	if hoist.IsJSONPatch(patch) {
+		ops, err := hoist.ParseJSONPatch(patch)
+		if err != nil {
+			return nil, 0, err
+		}
+		out, err := hoist.ApplyJSONPatch(data, ops, keepComments)
+		return out, len(ops), err
+	}
+	p, err := hoist.ParsePatch(patch)
+	if err != nil {
+		return nil, 0, err
+	}
+	out, err := p.Apply(data)
+	return out, len(p.Hunks), err
+}
+
+// applyPlan checks a saved plan against the files it was computed from and
+// returns the target's current and planned content, along with the number
+// of rules and settings the plan adds.
+func applyPlan(c *hoist.Client, f *hoist.PlanFile) (before, after []byte, n int, err error) {
+	plan, err := c.LoadPlan(f)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	before, after, err = c.Preview(plan)
+	ch := f.Changes
+	n = len(ch.Allow) + len(ch.Ask) + len(ch.Deny) + len(ch.EnableMCP) + len(ch.DisableMCP) + len(ch.Dirs) + len(ch.Env) + len(ch.Hooks)
+	if ch.EnableAll != nil {
+		n++
+	}
+	if ch.Mode != "" {
+		n++
+	}
+	return before, after, n, err
+}
+
+// applyTargetPath resolves --target: an edit target name, a path, or the
+// user config when empty.
+func applyTargetPath(c *hoist.Client, target string) string {
+	if target == "" {
+		return c.Path(hoist.ScopeUserLocal)
+	}
+	if scope, ok := editTargetScopes[target]; ok && scope != hoist.ScopeManaged {
+		return c.Path(scope)
+	}
+	return target
+}
+
+// completePatchFiles completes patch and plan files saved from diff or plan.
+func completePatchFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{"diff", "patch", "json", "plan"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+func init() {
+	applyCmd.Flags().String("target", "", "file to patch: a path or project, project-shared, user, user-shared")
+	applyCmd.RegisterFlagCompletionFunc("target", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"project", "project-shared", "user", "user-shared"}, cobra.ShellCompDirectiveDefault
+	})
+	applyCmd.Flags().Bool("check", false, "only check that the patch applies")
+	rootCmd.AddCommand(applyCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+// newClient returns a client for the current directory and user.
+func newClient() *hoist.Client {
+	c, err := hoist.New(hoist.Options{KeepComments: keepComments})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return c
+}
+
+// loadPlan computes what hoisting would change, narrowed by the command's
+// filter flags and widened by its --include flag.
+func loadPlan(cmd *cobra.Command) (*hoist.Client, *hoist.Plan, extras) {
+	opts := hoist.PlanOptions{Rules: true}
+	filter, err := loadFilter(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	opts.Filter = filter
+	extra, err := loadExtras(cmd, &opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	c := newClient()
+	plan, err := c.Plan(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return c, plan, extra
+}
+
+// writePlan writes the plan to the user config, noting when the file
+// changed since it was read and the plan was merged onto its new content.
+func writePlan(c *hoist.Client, plan *hoist.Plan) {
+	rebased, err := c.Apply(plan)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+		os.Exit(1)
+	}
+	if rebased {
+		fmt.Printf("note: %s changed while claude-hoist was running; merged into its current content\n", plan.UserPath)
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate a shell completion script",
+	Long: `Prints a completion script for the given shell. Besides commands and flags it
+completes edit targets and the rule strings in your settings files.
+
+  bash:        source <(claude-hoist completion bash)
+  zsh:         claude-hoist completion zsh > "${fpath[1]}/_claude-hoist"
+  fish:        claude-hoist completion fish > ~/.config/fish/completions/claude-hoist.fish
+  powershell:  claude-hoist completion powershell | Out-String | Invoke-Expression`,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(out)
+		case "fish":
+			return rootCmd.GenFishCompletion(out, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(out)
+		}
+	},
+}
+
+// completeRules completes rule strings from the settings files of every
+// scope, described by the list and scope they come from.
+func completeRules(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c, err := hoist.New(hoist.Options{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	scopes, _ := c.LoadScopes()
+	return ruleCompletions(scopes, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeToolCalls completes tool names, followed by "(" so an argument can
+// be typed straight away, and the rule strings in the settings files.
+func completeToolCalls(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	prefix := unquote(toComplete)
+	if !strings.Contains(prefix, "(") {
+		var comps []string
+		for _, tool := range hoist.BuiltinTools {
+			if strings.HasPrefix(tool, prefix) {
+				comps = append(comps, tool+"(")
+			}
+		}
+		return comps, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+	return completeRules(cmd, args, toComplete)
+}
+
+// ruleCompletions returns the rules in scopes that start with toComplete,
+// each once, described by where it is set.
+func ruleCompletions(scopes []hoist.ScopeSettings, toComplete string) []string {
+	prefix := unquote(toComplete)
+	seen := map[string]bool{}
+	var comps []string
+	for _, list := range hoist.Lists {
+		for _, sc := range scopes {
+			for _, r := range sc.Settings.Permissions.Rules(list) {
+				if seen[r] || !strings.HasPrefix(r, prefix) {
+					continue
+				}
+				seen[r] = true
+				comps = append(comps, cobra.CompletionWithDesc(r, string(list)+" · "+sc.Scope.String()))
+			}
+		}
+	}
+	sort.Strings(comps)
+	return comps
+}
+
+// unquote drops a leading quote the shell passed along with a partial word.
+func unquote(s string) string {
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`) {
+		return s[1:]
+	}
+	return s
+}
+
+// completeList completes one element of a comma-separated flag value.
+func completeList(values []string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		done, last := "", toComplete
+		if i := strings.LastIndexByte(toComplete, ','); i >= 0 {
+			done, last = toComplete[:i+1], toComplete[i+1:]
+		}
+		var comps []string
+		for _, v := range values {
+			if strings.HasPrefix(v, last) && !strings.Contains(","+done, ","+v+",") {
+				comps = append(comps, done+v)
+			}
+		}
+		return comps, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// completeJSONFiles completes file arguments, offering only .json files.
+func completeJSONFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+)
+
+func TestRuleCompletions(t *testing.T) {
+	scopes := []hoist.ScopeSettings{
+		{Scope: hoist.ScopeProjectLocal, Settings: hoist.Settings{Permissions: hoist.Permissions{
+			Allow: []string{"Bash(npm run test:*)", "Read"},
+			Deny:  []string{"Bash(rm -rf /)"},
+		}}},
+		{Scope: hoist.ScopeUserLocal, Settings: hoist.Settings{Permissions: hoist.Permissions{
+			Allow: []string{"Bash(npm run test:*)", "WebSearch"},
+		}}},
+	}
+	got := ruleCompletions(scopes, "'Bash(")
+	want := []string{"Bash(npm run test:*)\tallow · project", "Bash(rm -rf /)\tdeny · project"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestCompleteList(t *testing.T) {
+	complete := completeList([]string{"mcp", "dirs", "mode"})
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{"mcp", "dirs", "mode"}},
+		{"m", []string{"mcp", "mode"}},
+		{"mcp,", []string{"mcp,dirs", "mcp,mode"}},
+		{"mcp,dirs,mo", []string{"mcp,dirs,mode"}},
+	}
+	for _, tt := range tests {
+		got, _ := complete(nil, nil, tt.in)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
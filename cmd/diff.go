@@ -12,28 +12,105 @@ import (
 var diffCmd = &cobra.Command{
 	Use:   "diff",
 	Short: "Show a unified diff of what would change in your user config",
+	Long: `Shows a unified diff of what would change in your user config.
+
+--semantic reports the change per rule instead, grouped by tool: rules
+added, removed, moved between lists, or made redundant by a broader rule,
+followed by changed settings such as defaultMode. Its output doesn't depend
+on formatting or on the order of rules in a list.
+
+--format jsonpatch prints the change as an RFC 6902 JSON Patch instead. Either
+a saved diff or a saved JSON Patch can be applied later with apply.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_, user, userPath, newAllow, newDeny, err := hoist.LoadBoth()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		c, plan, extra := loadPlan(cmd)
+
+		format, _ := cmd.Flags().GetString("format")
+		semantic, _ := cmd.Flags().GetBool("semantic")
+		sideBySide, _ := cmd.Flags().GetBool("side-by-side")
+		switch {
+		case format != "unified" && format != "jsonpatch":
+			fmt.Fprintf(os.Stderr, "error: unknown --format %q (want: unified, jsonpatch)\n", format)
+			os.Exit(1)
+		case format == "jsonpatch" && (semantic || sideBySide):
+			fmt.Fprintln(os.Stderr, "error: --format jsonpatch can't be combined with --semantic or --side-by-side")
 			os.Exit(1)
 		}
 
-		if len(newAllow) == 0 && len(newDeny) == 0 {
+		// On stderr, so the diff itself can be saved and applied.
+		printBlocked(os.Stderr, plan.Blocked)
+		if plan.Empty() {
+			if format == "jsonpatch" {
+				fmt.Println("[]")
+				return
+			}
 			fmt.Println("nothing to do — user config already has all project permissions")
 			return
 		}
 
-		merged := hoist.Merge(user, newAllow, newDeny)
+		extra.resolveEnv(plan, false)
+		before, after, err := c.Preview(plan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
 
-		before, _ := json.MarshalIndent(user, "", "  ")
-		after, _ := json.MarshalIndent(merged, "", "  ")
+		mode, _ := cmd.Flags().GetString("color")
+		color, err := useColor(mode, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if format == "jsonpatch" {
+			// Diff the real content, so masking can't change what the
+			// operations point at, and mask only the values printed.
+			ops, err := hoist.DiffJSONPatch(before, after)
+			if err == nil && !extra.reveal {
+				ops, err = hoist.MaskJSONPatch(ops)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			if ops == nil {
+				ops = []hoist.JSONPatchOp{}
+			}
+			data, _ := json.MarshalIndent(ops, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+		if !extra.reveal {
+			if before, err = hoist.MaskEnvJSON(before); err == nil {
+				after, err = hoist.MaskEnvJSON(after)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: can't mask secret-looking env values: %v; use --reveal to show them\n", err)
+				os.Exit(1)
+			}
+		}
+		if semantic {
+			fmt.Print(hoist.DiffSemantic(plan.User, plan.Settings()).Render(color))
+			return
+		}
+		style := hoist.DiffStyle{Color: color}
+		style.SideBySide = sideBySide
+		if style.SideBySide {
+			style.Width = terminalWidth(os.Stdout)
+		}
 
-		d := hoist.UnifiedDiff(userPath, userPath+" (merged)", string(before)+"\n", string(after)+"\n")
+		d := hoist.RenderDiff(plan.UserPath, plan.UserPath+" (merged)", string(before), string(after), style)
 		fmt.Print(d)
 	},
 }
 
 func init() {
+	addFilterFlags(diffCmd)
+	addColorFlag(diffCmd)
+	diffCmd.Flags().Bool("side-by-side", false, "show old and new settings in two columns")
+	diffCmd.Flags().Bool("semantic", false, "report changes per rule, grouped by tool, instead of a text diff")
+	diffCmd.MarkFlagsMutuallyExclusive("semantic", "side-by-side")
+	diffCmd.Flags().String("format", "unified", "output format: unified or jsonpatch")
+	diffCmd.RegisterFlagCompletionFunc("format", completeList([]string{"unified", "jsonpatch"}))
+	addIncludeFlags(diffCmd)
 	rootCmd.AddCommand(diffCmd)
 }
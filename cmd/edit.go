@@ -1,65 +1,408 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/jeffrydegrande/claude-hoist/hoist"
 	"github.com/spf13/cobra"
 )
 
+// editTargets lists the files edit can open, in the order shown by completion.
+var editTargets = []string{"project", "project-shared", "user", "user-shared", "managed", "claude-json"}
+
 var editCmd = &cobra.Command{
-	Use:   "edit [project|user]",
-	Short: "Open project or user settings in $EDITOR",
-	Long: `Opens the Claude settings.local.json file in your $EDITOR.
-
-  claude-hoist edit project   opens .claude/settings.local.json in the current directory
-  claude-hoist edit user      opens ~/.claude/settings.local.json
-  claude-hoist edit           defaults to project`,
-	Args:      cobra.MaximumNArgs(1),
-	ValidArgs: []string{"project", "user"},
+	Use:   "edit [target]",
+	Short: "Open a settings file in your editor",
+	Long: `Opens a Claude settings file in $VISUAL, or $EDITOR when $VISUAL isn't set.
+The editor command may include arguments, e.g. EDITOR="code --wait".
+
+  claude-hoist edit project          .claude/settings.local.json in the current directory
+  claude-hoist edit project-shared   .claude/settings.json in the current directory
+  claude-hoist edit user             ~/.claude/settings.local.json
+  claude-hoist edit user-shared      ~/.claude/settings.json
+  claude-hoist edit managed          the managed settings file, read-only
+  claude-hoist edit claude-json      ~/.claude.json
+  claude-hoist edit                  defaults to project
+
+A missing file can be created with a minimal skeleton first; local files
+(settings.local.json and ~/.claude.json) are created readable only by you.
+
+The editor works on a temporary copy. When it exits the copy is validated and
+only replaces the settings file once it is valid; on errors you can edit it
+again, restore the original or keep it anyway. If the settings file changed
+while the editor was open, it is left alone and the copy is kept for you to
+merge. Managed settings are opened as a copy and any changes are discarded.
+
+--rule opens the file at the line holding a rule, using +N for vi, emacs,
+nano and similar editors and --goto file:N for VS Code and its forks.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: editTargets,
 	Run: func(cmd *cobra.Command, args []string) {
 		target := "project"
 		if len(args) > 0 {
 			target = args[0]
 		}
 
-		var path string
-		var err error
-
-		switch target {
-		case "project":
-			path, err = hoist.FindProjectSettings()
-		case "user":
-			path, err = hoist.UserSettingsPath()
-		default:
-			fmt.Fprintf(os.Stderr, "unknown target %q — use 'project' or 'user'\n", target)
-			os.Exit(1)
-		}
-
+		c := newClient()
+		path, local, err := editTargetPath(c, target)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
 			os.Exit(1)
 		}
+		rule, _ := cmd.Flags().GetString("rule")
 
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = "vi"
+		if target == "managed" {
+			if err := viewReadOnly(path, rule); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			return
 		}
 
-		c := exec.Command(editor, path)
-		c.Stdin = os.Stdin
-		c.Stdout = os.Stdout
-		c.Stderr = os.Stderr
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			fmt.Printf("%s doesn't exist. Create it? [y/N] ", path)
+			var answer string
+			fmt.Scanln(&answer)
+			if answer != "y" && answer != "Y" {
+				return
+			}
+			skeleton := settingsSkeleton
+			if target == "claude-json" {
+				skeleton = "{}\n"
+			}
+			if err := scaffold(path, skeleton, local); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
-		if err := c.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "editor exited with error: %v\n", err)
+		check := func(path string, data []byte) bool { return reportIssues(os.Stderr, path, data) }
+		if target == "claude-json" {
+			// ~/.claude.json is app state rather than settings; only its syntax is checked.
+			check = reportSyntax
+		}
+		if err := editValidated(c, path, rule, check); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
 			os.Exit(1)
 		}
 	},
 }
 
+// settingsSkeleton is what a newly created settings file starts with.
+const settingsSkeleton = `{
+  "permissions": {
+    "allow": [],
+    "deny": []
+  }
+}
+`
+
+// editTargetScopes maps the edit targets that are settings scopes to them.
+var editTargetScopes = map[string]hoist.Scope{
+	"project":        hoist.ScopeProjectLocal,
+	"project-shared": hoist.ScopeProjectShared,
+	"user":           hoist.ScopeUserLocal,
+	"user-shared":    hoist.ScopeUserShared,
+	"managed":        hoist.ScopeManaged,
+}
+
+// editTargetPath resolves an edit target to its file and reports whether it
+// is a local (per-user, private) file.
+func editTargetPath(c *hoist.Client, target string) (path string, local bool, err error) {
+	if target == "claude-json" {
+		return c.ClaudeJSONPath(), true, nil
+	}
+	scope, ok := editTargetScopes[target]
+	if !ok {
+		return "", false, fmt.Errorf("unknown target %q — use one of %s", target, strings.Join(editTargets, ", "))
+	}
+	return c.Path(scope), scope == hoist.ScopeProjectLocal || scope == hoist.ScopeUserLocal, nil
+}
+
+// completeEditRules completes --rule with the rules in the file being edited.
+func completeEditRules(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	target := "project"
+	if len(args) > 0 {
+		target = args[0]
+	}
+	scope, ok := editTargetScopes[target]
+	c, err := hoist.New(hoist.Options{})
+	if !ok || err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	path := c.Path(scope)
+	s, err := c.ReadSettings(path)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return ruleCompletions([]hoist.ScopeSettings{{Scope: scope, Path: path, Settings: s}}, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// scaffold creates path with content, along with any missing parent
+// directories. Local files are made readable only by their owner.
+func scaffold(path, content string, local bool) error {
+	perm := fs.FileMode(0644)
+	if local {
+		perm = 0600
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// viewReadOnly opens a copy of path in the editor and discards any changes.
+func viewReadOnly(path, rule string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no managed settings at %s", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp("", "managed-settings.*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	os.Chmod(tmp.Name(), 0400)
+
+	line := 0
+	if rule != "" {
+		line = hoist.RuleLine(data, rule)
+	}
+	if err := runEditor(tmp.Name(), line); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+	if after, err := os.ReadFile(tmp.Name()); err == nil && !bytes.Equal(after, data) {
+		fmt.Println("Managed settings are read-only; changes discarded.")
+	}
+	return nil
+}
+
+// editValidated opens a temporary copy of path in the editor and swaps it in
+// once check accepts it, like visudo or crontab -e. check prints any problems
+// and reports whether the content must not be saved as is. If path changed
+// while the editor was open, such as by Claude Code approving a prompt,
+// nothing is swapped in and the copy is kept for the user to merge by hand.
+func editValidated(c *hoist.Client, path, rule string, check func(path string, data []byte) bool) error {
+	// Replace the file a symlinked settings file points to, not the link.
+	path = hoist.ResolveSymlinks(path)
+	orig, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	mode := fs.FileMode(0600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	// Keep the copy next to the original so it can be renamed into place.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.json")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(tmpPath)
+		}
+	}()
+	_, err = tmp.Write(orig)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	data := orig
+	for {
+		line := 0
+		if rule != "" {
+			if line = hoist.RuleLine(data, rule); line == 0 {
+				fmt.Fprintf(os.Stderr, "warning: rule %q not found in %s\n", rule, path)
+			}
+		}
+		if err := runEditor(tmpPath, line); err != nil {
+			return fmt.Errorf("editor exited with error: %w", err)
+		}
+		data, err = os.ReadFile(tmpPath)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(data, orig) {
+			fmt.Println("No changes.")
+			return nil
+		}
+
+		if check(path, data) {
+			fmt.Print("What now? [e]dit again, [R]estore original, [k]eep anyway: ")
+			var answer string
+			fmt.Scanln(&answer)
+			switch answer {
+			case "e", "E":
+				continue
+			case "k", "K":
+			default:
+				// Anything else, including no answer or EOF, keeps the
+				// original rather than asking forever.
+				fmt.Println("Restored original.")
+				return nil
+			}
+		}
+
+		if err := os.Chmod(tmpPath, mode); err != nil {
+			return err
+		}
+		return swapEdited(c, path, tmpPath, orig, &keep)
+	}
+}
+
+// swapEdited renames the edited copy over path while holding its lock,
+// unless path no longer has the content orig the copy was made from. Then
+// it sets keep so the copy survives, and fails.
+func swapEdited(c *hoist.Client, path, tmpPath string, orig []byte, keep *bool) error {
+	unlock, err := c.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cur, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if !bytes.Equal(cur, orig) {
+		*keep = true
+		return fmt.Errorf("%s changed while you were editing it; nothing written, your version is in %s", path, tmpPath)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// runEditor opens path in the user's editor, at line when it's positive.
+func runEditor(path string, line int) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	argv, err := splitWords(editor)
+	if err != nil {
+		return fmt.Errorf("parsing editor command %q: %w", editor, err)
+	}
+	if len(argv) == 0 {
+		return fmt.Errorf("empty editor command")
+	}
+
+	c := exec.Command(argv[0], append(argv[1:], editorArgs(argv[0], path, line)...)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// editorArgs returns the arguments that open path at line in the given editor.
+// Editors that aren't known to take a line number just get the path.
+func editorArgs(editor, path string, line int) []string {
+	if line <= 0 {
+		return []string{path}
+	}
+	name := strings.TrimSuffix(filepath.Base(editor), ".exe")
+	switch name {
+	case "vi", "vim", "nvim", "gvim", "mvim", "view", "emacs", "emacsclient", "nano", "pico", "micro", "kak", "joe", "mg", "ne":
+		return []string{"+" + strconv.Itoa(line), path}
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return []string{"--goto", path + ":" + strconv.Itoa(line)}
+	case "subl", "hx", "zed":
+		return []string{path + ":" + strconv.Itoa(line)}
+	}
+	return []string{path}
+}
+
+// splitWords splits a command line into words the way a POSIX shell would,
+// honouring single quotes, double quotes and backslash escapes. Expansions
+// and operators are not supported.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case c == '\\':
+			if i+1 >= len(s) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			word.WriteByte(s[i])
+			inWord = true
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
 func init() {
+	editCmd.Flags().String("rule", "", "open the file at the line holding this rule")
+	editCmd.RegisterFlagCompletionFunc("rule", completeEditRules)
 	rootCmd.AddCommand(editCmd)
 }
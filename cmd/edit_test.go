@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"vim", []string{"vim"}},
+		{"code --wait", []string{"code", "--wait"}},
+		{`  "/Applications/Sublime Text.app/subl" -w `, []string{"/Applications/Sublime Text.app/subl", "-w"}},
+		{`emacsclient -a '' -t`, []string{"emacsclient", "-a", "", "-t"}},
+		{`my\ editor "a\"b"`, []string{"my editor", `a"b`}},
+	}
+	for _, tt := range tests {
+		got, err := splitWords(tt.in)
+		if err != nil {
+			t.Errorf("splitWords(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{`vim "x`, `vim 'x`, `vim \`} {
+		if _, err := splitWords(in); err == nil {
+			t.Errorf("splitWords(%q): expected error", in)
+		}
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		editor string
+		line   int
+		want   []string
+	}{
+		{"vi", 0, []string{"f.json"}},
+		{"/usr/bin/nvim", 7, []string{"+7", "f.json"}},
+		{"nano", 3, []string{"+3", "f.json"}},
+		{"code", 7, []string{"--goto", "f.json:7"}},
+		{"ed", 7, []string{"f.json"}},
+	}
+	for _, tt := range tests {
+		if got := editorArgs(tt.editor, "f.json", tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("editorArgs(%q, %d) = %q, want %q", tt.editor, tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSwapEditedRefusesConcurrentChange(t *testing.T) {
+	dir := t.TempDir()
+	c, err := hoist.New(hoist.Options{ProjectDir: dir, Home: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "settings.json")
+	tmpPath := filepath.Join(dir, "edited.json")
+	os.WriteFile(path, []byte(`{"a": 2}`), 0600)
+	os.WriteFile(tmpPath, []byte(`{"a": 3}`), 0600)
+
+	keep := false
+	if err := swapEdited(c, path, tmpPath, []byte(`{"a": 1}`), &keep); err == nil || !keep {
+		t.Fatalf("got %v, keep %v", err, keep)
+	}
+	if data, _ := os.ReadFile(path); string(data) != `{"a": 2}` {
+		t.Errorf("file overwritten: %s", data)
+	}
+
+	if err := swapEdited(c, path, tmpPath, []byte(`{"a": 2}`), &keep); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != `{"a": 3}` {
+		t.Errorf("got %s", data)
+	}
+}
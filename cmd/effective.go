@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var effectiveCmd = &cobra.Command{
+	Use:   "effective",
+	Short: "Show the combined permission rules from every settings scope",
+	Long: `Combines managed, project and user settings the way Claude Code does and
+lists every rule with the file it comes from.
+
+Rules are listed deny first, then ask, then allow. A rule is marked shadowed
+when an earlier rule in the same list already covers it, and overridden when
+a deny (or ask) rule covers it so it can never apply.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		scopes, err := newClient().LoadScopes()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		filter, err := loadFilter(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Filter after combining so statuses still account for every rule.
+		var rules []hoist.EffectiveRule
+		for _, r := range hoist.Effective(scopes) {
+			if filter.Keep(r.List, r.Rule) {
+				rules = append(rules, r)
+			}
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			data, _ := json.MarshalIndent(rules, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		if len(rules) == 0 {
+			fmt.Println("no permission rules in any settings file")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LIST\tRULE\tSCOPE\tSTATUS")
+		for _, r := range rules {
+			status := string(r.Status)
+			if r.By != "" {
+				status += " by " + r.By
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.List, r.Rule, r.Scope, status)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	effectiveCmd.Flags().Bool("json", false, "output as JSON")
+	addFilterFlags(effectiveCmd)
+	rootCmd.AddCommand(effectiveCmd)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [tool-call]",
+	Short: "Show which permission rule decides a tool call",
+	Long: `Evaluates a tool call against managed, project and user settings and prints the
+rule that decides it, plus any other rules that match.
+
+  claude-hoist explain 'Bash(git push origin main)'
+  echo '{"tool_name":"Bash","tool_input":{"command":"git push"}}' | claude-hoist explain
+
+Deny rules are checked first, then ask, then allow. Within a list, managed
+settings win over project local, then project shared, then user local, then
+user shared.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeToolCalls,
+	Run: func(cmd *cobra.Command, args []string) {
+		var call hoist.ToolCall
+		var err error
+		if len(args) > 0 {
+			call, err = hoist.ParseToolCall(args[0])
+		} else {
+			var data []byte
+			data, err = io.ReadAll(os.Stdin)
+			if err == nil {
+				call, err = hoist.ParseToolCallJSON(data)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// A call read from a hook names the directory it runs in.
+		c, err := hoist.New(hoist.Options{ProjectDir: call.Cwd, KeepComments: keepComments})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		call.Cwd, call.Home = c.ProjectDir(), c.Home()
+
+		scopes, err := c.LoadScopes()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		matches := hoist.Explain(call, scopes)
+		if len(matches) == 0 {
+			fmt.Printf("%s\n\nno rule matches — Claude Code will ask\n", call)
+			return
+		}
+
+		win := matches[0]
+		fmt.Printf("%s\n\n%s — %s from %s (%s)\n", call, win.List, win.Rule, win.Scope, win.Path)
+
+		if len(matches) > 1 {
+			fmt.Printf("\nAlso matching (%d):\n", len(matches)-1)
+			for _, m := range matches[1:] {
+				fmt.Printf("  %-5s %s  [%s]\n", m.List, m.Rule, m.Scope)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+// addFilterFlags registers the flags that narrow which rules a command handles.
+func addFilterFlags(c *cobra.Command) {
+	c.Flags().StringSlice("tool", nil, "only rules for these tools, e.g. Bash,Read")
+	c.Flags().StringSlice("list", nil, "only rules from these lists (allow, ask, deny)")
+	c.Flags().StringSlice("match", nil, "only rules matching this glob, e.g. 'Bash(git *'")
+	c.Flags().StringSlice("exclude", nil, "skip rules matching this glob")
+	c.Flags().StringSlice("mcp-server", nil, "only rules for tools on these MCP servers")
+
+	c.RegisterFlagCompletionFunc("tool", completeFromScopes(ruleTools))
+	c.RegisterFlagCompletionFunc("list", completeList([]string{"allow", "ask", "deny"}))
+	c.RegisterFlagCompletionFunc("mcp-server", completeFromScopes(ruleServers))
+}
+
+// loadFilter builds the filter selected with the flags from addFilterFlags.
+func loadFilter(cmd *cobra.Command) (hoist.Filter, error) {
+	var f hoist.Filter
+	f.Tools, _ = cmd.Flags().GetStringSlice("tool")
+	f.Match, _ = cmd.Flags().GetStringSlice("match")
+	f.Exclude, _ = cmd.Flags().GetStringSlice("exclude")
+	f.MCPServers, _ = cmd.Flags().GetStringSlice("mcp-server")
+	lists, _ := cmd.Flags().GetStringSlice("list")
+	for _, name := range lists {
+		l, err := hoist.ParseList(name)
+		if err != nil {
+			return hoist.Filter{}, err
+		}
+		f.Lists = append(f.Lists, l)
+	}
+	return f, nil
+}
+
+// completeFromScopes completes a comma-separated flag value with the names
+// values extracts from the settings of every scope.
+func completeFromScopes(values func([]hoist.ScopeSettings) []string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, err := hoist.New(hoist.Options{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		scopes, _ := c.LoadScopes()
+		return completeList(values(scopes))(cmd, args, toComplete)
+	}
+}
+
+// ruleTools returns the built-in tools plus every tool named by a rule.
+func ruleTools(scopes []hoist.ScopeSettings) []string {
+	seen := map[string]bool{}
+	for _, t := range hoist.BuiltinTools {
+		seen[t] = true
+	}
+	eachRule(scopes, func(rule string) {
+		if r, err := hoist.ParseRule(rule); err == nil {
+			seen[r.Tool] = true
+		}
+	})
+	return sortedKeys(seen)
+}
+
+// ruleServers returns the MCP servers named by rules or enabled in settings.
+func ruleServers(scopes []hoist.ScopeSettings) []string {
+	seen := map[string]bool{}
+	eachRule(scopes, func(rule string) {
+		if server, ok := hoist.MCPServer(rule); ok {
+			seen[server] = true
+		}
+	})
+	for _, sc := range scopes {
+		for _, server := range sc.Settings.EnabledMcpjsonServers {
+			seen[server] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+func eachRule(scopes []hoist.ScopeSettings, fn func(string)) {
+	for _, sc := range scopes {
+		for _, list := range hoist.Lists {
+			for _, rule := range sc.Settings.Permissions.Rules(list) {
+				fn(rule)
+			}
+		}
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
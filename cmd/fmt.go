@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path...]",
+	Short: "Normalize settings files",
+	Long: `Checks that each settings file parses and rewrites it as strict, consistently
+indented JSON. Comments and trailing commas are removed; pass --keep-comments
+to keep comments, in which case only trailing commas are removed.
+
+Without arguments, formats the project's .claude/settings.local.json.`,
+	ValidArgsFunction: completeJSONFiles,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+		paths := args
+		if len(paths) == 0 {
+			p := c.Path(hoist.ScopeProjectLocal)
+			if !c.Exists(p) {
+				fmt.Fprintf(os.Stderr, "error: no .claude/settings.local.json in %s\n", c.ProjectDir())
+				os.Exit(1)
+			}
+			paths = []string{p}
+		}
+
+		check, _ := cmd.Flags().GetBool("check")
+		failed := false
+		for _, path := range paths {
+			data, err := c.FS().ReadFile(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				failed = true
+				continue
+			}
+
+			out, err := hoist.FormatJSONC(data, keepComments)
+			if err != nil {
+				var syn *hoist.SyntaxError
+				if errors.As(err, &syn) {
+					syn.Path = path
+				}
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				failed = true
+				continue
+			}
+
+			if bytes.Equal(out, data) {
+				continue
+			}
+			if check {
+				fmt.Println(path)
+				failed = true
+				continue
+			}
+			if err := c.FS().WriteFile(path, out, 0600); err != nil {
+				fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+				failed = true
+				continue
+			}
+			fmt.Printf("formatted %s\n", path)
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	fmtCmd.Flags().Bool("check", false, "list files that need formatting instead of rewriting them")
+	rootCmd.AddCommand(fmtCmd)
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+// hookEvents are the Claude Code events hook install can register for.
+var hookEvents = []string{"SessionEnd", "Stop"}
+
+// hookPolicySlices and hookPolicyBools are the hook run flags hook install
+// passes through.
+var (
+	hookPolicySlices = []string{"tool", "list", "match", "exclude", "mcp-server", "include"}
+	hookPolicyBools  = []string{"queue", "allow-secrets"}
+)
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Run claude-hoist from a Claude Code hook",
+	Long: `Instead of running watch, claude-hoist can be called by Claude Code itself
+when a session ends:
+
+  claude-hoist hook install --exclude 'Bash(rm *'
+  claude-hoist hook install --queue
+
+install registers a SessionEnd hook (or Stop, with --event) in your user
+config that runs hook run. The filter flags, --include, --allow-secrets and
+--queue given to install are passed on to hook run and decide what it does:
+hoist new rules from the session's project, as add -y would, or only add them
+to the review queue in ~/.claude/hoist-queue.json. Installing again replaces
+the hook; uninstall removes it.
+
+Hoisted and queued rules are logged to ~/.claude/claude-hoist.log.`,
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Register a hook that runs hook run",
+	Run: func(cmd *cobra.Command, args []string) {
+		event, _ := cmd.Flags().GetString("event")
+		known := false
+		for _, e := range hookEvents {
+			known = known || event == e
+		}
+		if !known {
+			fmt.Fprintf(os.Stderr, "error: unknown --event %q (want: %s)\n", event, strings.Join(hookEvents, ", "))
+			os.Exit(1)
+		}
+		if _, err := loadFilter(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		command := hookRunCommand(cmd)
+
+		c := newClient()
+		path := c.Path(hoist.ScopeUserLocal)
+		var replaced int
+		err := c.UpdateSettings(path, func(s *hoist.Settings) error {
+			*s, replaced = hoist.RemoveHooks(*s, isHoistHook)
+			*s = hoist.MergeHooks(*s, []hoist.HookChange{{
+				Event: event,
+				Hook:  hoist.HookCommand{Type: "command", Command: command},
+			}})
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			os.Exit(1)
+		}
+		if replaced > 0 {
+			fmt.Printf("replaced the claude-hoist hook in %s\n", path)
+		} else {
+			fmt.Printf("installed a %s hook in %s\n", event, path)
+		}
+		fmt.Printf("  %s\n", command)
+	},
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the hook install registered",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+		path := c.Path(hoist.ScopeUserLocal)
+		var removed int
+		err := c.UpdateSettings(path, func(s *hoist.Settings) error {
+			*s, removed = hoist.RemoveHooks(*s, isHoistHook)
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			os.Exit(1)
+		}
+		if removed == 0 {
+			fmt.Printf("no claude-hoist hook in %s\n", path)
+			return
+		}
+		fmt.Printf("removed %d claude-hoist hook(s) from %s\n", removed, path)
+	},
+}
+
+var hookRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Hoist or queue a session's new rules; called by the hook",
+	Long: `Reads the hook payload Claude Code sends on stdin, and hoists the new rules
+of the session's project, or queues them with --queue. The project is
+$CLAUDE_PROJECT_DIR when Claude Code sets it, and otherwise the nearest
+directory at or above the payload's cwd with a .claude/settings.local.json;
+without one nothing is done. Nothing is printed unless something goes wrong.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var payload struct {
+			SessionID string `json:"session_id"`
+			Cwd       string `json:"cwd"`
+		}
+		if err := json.NewDecoder(os.Stdin).Decode(&payload); err != nil {
+			fmt.Fprintf(os.Stderr, "error: reading hook payload: %v\n", err)
+			os.Exit(1)
+		}
+		if payload.Cwd == "" {
+			fmt.Fprintln(os.Stderr, "error: hook payload has no cwd")
+			os.Exit(1)
+		}
+
+		w := &hoister{out: io.Discard, opts: hoist.PlanOptions{Rules: true, SkipDismissed: true}, projects: map[string]*watchedProject{}}
+		var err error
+		if w.opts.Filter, err = loadFilter(cmd); err == nil {
+			w.extra, err = loadExtras(cmd, &w.opts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		w.queue, _ = cmd.Flags().GetBool("queue")
+		if payload.SessionID != "" {
+			w.note = " (session " + payload.SessionID + ")"
+		}
+
+		c, err := hoist.New(hoist.Options{ProjectDir: hookProjectDir(payload.Cwd), KeepComments: keepComments})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		logFile, _, err := openLog(cmd, c)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		defer logFile.Close()
+		w.log = logFile
+
+		path := c.Path(hoist.ScopeProjectLocal)
+		w.projects[path] = &watchedProject{c: c}
+		w.hoist(path)
+	},
+}
+
+// hookProjectDir returns the project a hook fired for: $CLAUDE_PROJECT_DIR,
+// or the nearest directory at or above cwd with .claude/settings.local.json.
+// The home directory is never a match, since its .claude holds the user
+// config. When nothing matches it returns cwd.
+func hookProjectDir(cwd string) string {
+	if dir := os.Getenv("CLAUDE_PROJECT_DIR"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	for dir := filepath.Clean(cwd); dir != home; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".claude", "settings.local.json")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	return cwd
+}
+
+// hookRunCommand returns the command line install registers: this binary's
+// hook run, with the policy flags given to install.
+func hookRunCommand(cmd *cobra.Command) string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "claude-hoist"
+	}
+	args := []string{shellQuote(exe), "hook", "run"}
+	for _, name := range hookPolicySlices {
+		if cmd.Flags().Changed(name) {
+			values, _ := cmd.Flags().GetStringSlice(name)
+			for _, v := range values {
+				args = append(args, "--"+name, shellQuote(v))
+			}
+		}
+	}
+	for _, name := range hookPolicyBools {
+		if on, _ := cmd.Flags().GetBool(name); on {
+			args = append(args, "--"+name)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// isHoistHook reports whether h runs claude-hoist hook run, or this binary's
+// hook run if it was renamed.
+func isHoistHook(event string, h hoist.HookCommand) bool {
+	words, err := splitWords(h.Command)
+	if err != nil || len(words) < 3 || words[1] != "hook" || words[2] != "run" {
+		return false
+	}
+	self, _ := os.Executable()
+	return strings.TrimSuffix(filepath.Base(words[0]), ".exe") == "claude-hoist" || words[0] == self
+}
+
+// shellQuote quotes s for a POSIX shell when it holds anything but plain
+// word characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-./:=@%+,") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func init() {
+	for _, c := range []*cobra.Command{hookInstallCmd, hookRunCmd} {
+		addFilterFlags(c)
+		addIncludeFlags(c)
+		c.Flags().Bool("queue", false, "only add new rules to the review queue")
+	}
+	hookInstallCmd.Flags().String("event", "SessionEnd", "Claude Code event to run on ("+strings.Join(hookEvents, ", ")+")")
+	hookInstallCmd.RegisterFlagCompletionFunc("event", completeList(hookEvents))
+	hookRunCmd.Flags().String("log", "", "file to log hoisted and queued rules to (default ~/.claude/claude-hoist.log)")
+	hookCmd.AddCommand(hookInstallCmd, hookRunCmd, hookUninstallCmd)
+	rootCmd.AddCommand(hookCmd)
+}
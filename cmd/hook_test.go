@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+)
+
+func TestIsHoistHook(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"claude-hoist hook run", true},
+		{"/home/me/go/bin/claude-hoist hook run --queue", true},
+		{"'/Users/me/my tools/claude-hoist' hook run --exclude 'Bash(rm *'", true},
+		{"claude-hoist hooks add", false},
+		{"claude-hoist hook runner", false},
+		{"notify-send done", false},
+		{"/usr/bin/other hook run", false},
+	}
+	for _, tt := range tests {
+		if got := isHoistHook("SessionEnd", hoist.HookCommand{Type: "command", Command: tt.command}); got != tt.want {
+			t.Errorf("isHoistHook(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/usr/local/bin/claude-hoist", "/usr/local/bin/claude-hoist"},
+		{"Bash(rm *", "'Bash(rm *'"},
+		{"it's", `'it'\''s'`},
+		{"", "''"},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if words, err := splitWords(shellQuote(tt.in)); err != nil || len(words) != 1 || words[0] != tt.in {
+			t.Errorf("splitWords(shellQuote(%q)) = %q, %v", tt.in, words, err)
+		}
+	}
+}
+
+func TestHookProjectDir(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	t.Setenv("CLAUDE_PROJECT_DIR", "")
+	project := filepath.Join(root, "work", "app")
+	sub := filepath.Join(project, "internal", "server")
+	os.MkdirAll(sub, 0700)
+	os.MkdirAll(filepath.Join(project, ".claude"), 0700)
+	os.WriteFile(filepath.Join(project, ".claude", "settings.local.json"), []byte("{}"), 0600)
+
+	for _, cwd := range []string{project, sub} {
+		if got := hookProjectDir(cwd); got != project {
+			t.Errorf("hookProjectDir(%q) = %q, want %q", cwd, got, project)
+		}
+	}
+	other := filepath.Join(root, "work")
+	if got := hookProjectDir(other); got != other {
+		t.Errorf("no project above %q: got %q", other, got)
+	}
+
+	// The user config in ~/.claude is not a project.
+	home := filepath.Join(root, "home")
+	inHome := filepath.Join(home, "scratch")
+	os.MkdirAll(filepath.Join(home, ".claude"), 0700)
+	os.MkdirAll(inHome, 0700)
+	os.WriteFile(filepath.Join(home, ".claude", "settings.local.json"), []byte("{}"), 0600)
+	if got := hookProjectDir(inHome); got != inHome {
+		t.Errorf("matched the home directory: got %q", got)
+	}
+
+	t.Setenv("CLAUDE_PROJECT_DIR", other)
+	if got := hookProjectDir(sub); got != other {
+		t.Errorf("with $CLAUDE_PROJECT_DIR: got %q, want %q", got, other)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Hoist project hooks to your user config",
+	Long: `Compares the hooks configured in the project's .claude/settings.local.json
+and committed .claude/settings.json with your user config, per event and
+matcher.
+
+Hooks that use a path relative to the project (scripts/fmt.sh, node
+tools/lint.js or $CLAUDE_PROJECT_DIR/...) are flagged, since they won't
+resolve in other repos.`,
+}
+
+var hooksShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show project hooks that aren't in your user config yet",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, plan := loadHookPlan()
+		if len(plan.Hooks) == 0 {
+			fmt.Println("nothing new — all project hooks already exist in user config")
+			return
+		}
+		printHookChanges(plan.Hooks)
+	},
+}
+
+var hooksAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add all project hooks to your user config",
+	Run: func(cmd *cobra.Command, args []string) {
+		c, plan := loadHookPlan()
+		if len(plan.Hooks) == 0 {
+			fmt.Println("nothing to do — all project hooks already exist in user config")
+			return
+		}
+		printHookChanges(plan.Hooks)
+
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes {
+			fmt.Printf("\nMerge into %s? [y/N] ", plan.UserPath)
+			var answer string
+			fmt.Scanln(&answer)
+			if answer != "y" && answer != "Y" {
+				fmt.Println("aborted")
+				return
+			}
+		}
+
+		writePlan(c, plan)
+
+		fmt.Printf("done — wrote %s\n", plan.UserPath)
+	},
+}
+
+var hooksStepCmd = &cobra.Command{
+	Use:   "step",
+	Short: "Step through each new hook one by one",
+	Run: func(cmd *cobra.Command, args []string) {
+		c, plan := loadHookPlan()
+		changes := plan.Hooks
+		if len(changes) == 0 {
+			fmt.Println("nothing new — all project hooks already exist in user config")
+			return
+		}
+
+		// Hooks differing only in fields the label leaves out, such as
+		// timeout, share a label, so pick them by index.
+		labels := make([]string, len(changes))
+		for i, c := range changes {
+			labels[i] = c.String()
+			if c.Warning != "" {
+				labels[i] += "\n        ! " + c.Warning
+			}
+		}
+
+		fmt.Printf("Hooks (%d new):\n\n", len(changes))
+		var accepted []hoist.HookChange
+		for _, i := range stepIndexes(labels) {
+			accepted = append(accepted, changes[i])
+		}
+
+		if len(accepted) == 0 {
+			fmt.Println("\nnothing selected")
+			return
+		}
+
+		plan.Hooks = accepted
+		writePlan(c, plan)
+
+		fmt.Printf("\ndone — added %d hook(s) to %s\n", len(accepted), plan.UserPath)
+	},
+}
+
+// loadHookPlan computes the hooks hoisting would add, and nothing else.
+func loadHookPlan() (*hoist.Client, *hoist.Plan) {
+	c := newClient()
+	plan, err := c.Plan(hoist.PlanOptions{Hooks: true, SharedHooks: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return c, plan
+}
+
+func printHookChanges(changes []hoist.HookChange) {
+	fmt.Printf("New hooks (%d):\n", len(changes))
+	for _, c := range changes {
+		fmt.Printf("  + %s\n", c)
+		if c.Warning != "" {
+			fmt.Printf("      ! %s\n", c.Warning)
+		}
+	}
+}
+
+func init() {
+	hooksAddCmd.Flags().BoolP("yes", "y", false, "skip confirmation prompt")
+	hooksCmd.AddCommand(hooksShowCmd, hooksAddCmd, hooksStepCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
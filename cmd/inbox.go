@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List rules waiting for review from any project",
+	Long: `Lists the review queue in ~/.claude/hoist-queue.json: candidate rules from
+any project, with the project they were first seen in, when, and how many
+projects they have turned up in. watch --queue and hook run --queue fill it, and
+inbox add queues rules by hand.
+
+inbox step reviews the queue like step does: y hoists a rule into your user
+config, n leaves it queued, d dismisses it for good and q stops. Dismissed
+rules are never queued again, nor hoisted by watch or hook run. Rules that
+are already in your user config are dropped from the queue.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+		q := loadInbox(c)
+
+		if dismissed, _ := cmd.Flags().GetBool("dismissed"); dismissed {
+			if len(q.Dismissed) == 0 {
+				fmt.Println("no dismissed rules")
+				return
+			}
+			fmt.Printf("Dismissed rules (%d):\n", len(q.Dismissed))
+			for _, d := range q.Dismissed {
+				fmt.Printf("  - %s %s  (%s)\n", d.List, d.Rule, d.At.Local().Format(time.DateOnly))
+			}
+			return
+		}
+
+		if len(q.Entries) == 0 {
+			fmt.Println("inbox is empty")
+			return
+		}
+		fmt.Printf("Pending rules (%d):\n", len(q.Entries))
+		for _, e := range q.Entries {
+			fmt.Printf("  + %s %s\n      %s\n", e.List, e.Rule, describeEntry(e))
+		}
+	},
+}
+
+var inboxStepCmd = &cobra.Command{
+	Use:   "step",
+	Short: "Review queued rules one by one",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+		q := loadInbox(c)
+		if len(q.Entries) == 0 {
+			fmt.Println("inbox is empty")
+			return
+		}
+
+		managed, _, err := c.LoadManaged()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		var allow []string
+		for _, e := range q.Entries {
+			if e.List == hoist.ListAllow {
+				allow = append(allow, e.Rule)
+			}
+		}
+		_, blocked := hoist.Blocked(allow, managed)
+		printBlocked(os.Stdout, blocked)
+
+		var review []hoist.QueueEntry
+		for _, e := range q.Entries {
+			if _, ok := blocked[e.Rule]; !ok || e.List != hoist.ListAllow {
+				review = append(review, e)
+			}
+		}
+
+		var accepted, dismissed []hoist.QueueEntry
+	prompts:
+		for i, e := range review {
+			fmt.Printf("  [%d/%d] %s %s\n", i+1, len(review), e.List, e.Rule)
+			fmt.Printf("        %s\n", describeEntry(e))
+			fmt.Print("  add? [y/n/d/q] ")
+
+			var answer string
+			fmt.Scanln(&answer)
+
+			switch answer {
+			case "y", "Y":
+				accepted = append(accepted, e)
+			case "d", "D":
+				dismissed = append(dismissed, e)
+			case "q", "Q":
+				fmt.Println("  skipping remaining")
+				break prompts
+			default:
+				// leave it queued
+			}
+		}
+
+		if len(accepted) > 0 {
+			err := c.UpdateSettings(c.Path(hoist.ScopeUserLocal), func(s *hoist.Settings) error {
+				for _, e := range accepted {
+					*s = hoist.MergeRules(*s, e.List, []string{e.Rule})
+				}
+				return nil
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		err = c.UpdateQueue(func(q *hoist.Queue) error {
+			for _, e := range accepted {
+				q.Remove(e.List, e.Rule)
+			}
+			now := time.Now()
+			for _, e := range dismissed {
+				q.Dismiss(e.List, e.Rule, now)
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(accepted) == 0 && len(dismissed) == 0 {
+			fmt.Println("\nnothing selected")
+			return
+		}
+		fmt.Printf("\ndone — added %d rule(s) to %s, dismissed %d\n", len(accepted), c.Path(hoist.ScopeUserLocal), len(dismissed))
+	},
+}
+
+var inboxAddCmd = &cobra.Command{
+	Use:   "add <rule>...",
+	Short: "Queue rules for review",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+		list := inboxList(cmd)
+		for _, rule := range args {
+			if _, err := hoist.ParseRule(rule); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		now := time.Now()
+		err := c.UpdateQueue(func(q *hoist.Queue) error {
+			for _, rule := range args {
+				switch {
+				case q.IsDismissed(list, rule):
+					fmt.Printf("  ! %s %s was dismissed; not queued\n", list, rule)
+				case q.Add(list, rule, c.ProjectDir(), now):
+					fmt.Printf("  + %s %s\n", list, rule)
+				default:
+					fmt.Printf("  = %s %s is already queued\n", list, rule)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var inboxDismissCmd = &cobra.Command{
+	Use:               "dismiss <rule>...",
+	Short:             "Drop rules from the queue and never queue them again",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeInboxRules,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+		list := inboxList(cmd)
+		now := time.Now()
+		err := c.UpdateQueue(func(q *hoist.Queue) error {
+			for _, rule := range args {
+				q.Dismiss(list, rule, now)
+				fmt.Printf("  - %s %s\n", list, rule)
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// loadInbox reads the review queue, dropping the rules the user config
+// already has. The queue file is only written when something was dropped.
+func loadInbox(c *hoist.Client) *hoist.Queue {
+	user, err := c.ReadSettings(c.Path(hoist.ScopeUserLocal))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "error: reading user settings: %v\n", err)
+		os.Exit(1)
+	}
+	q, err := c.LoadQueue()
+	if err == nil && len(q.Prune(user)) > 0 {
+		// Prune again under the lock, in case the queue changed since.
+		var pruned []hoist.QueueEntry
+		err = c.UpdateQueue(func(queue *hoist.Queue) error {
+			pruned = queue.Prune(user)
+			q = queue
+			return nil
+		})
+		if err == nil && len(pruned) > 0 {
+			fmt.Printf("dropped %d queued rule(s) already in your user config\n\n", len(pruned))
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return q
+}
+
+// describeEntry says where a queued rule was seen.
+func describeEntry(e hoist.QueueEntry) string {
+	return fmt.Sprintf("from %s, first seen %s, seen in %d project(s)", e.Project, e.FirstSeen.Local().Format(time.DateOnly), e.Count)
+}
+
+// inboxList returns the list selected with --ask or --deny.
+func inboxList(cmd *cobra.Command) hoist.List {
+	if deny, _ := cmd.Flags().GetBool("deny"); deny {
+		return hoist.ListDeny
+	}
+	if ask, _ := cmd.Flags().GetBool("ask"); ask {
+		return hoist.ListAsk
+	}
+	return hoist.ListAllow
+}
+
+// completeInboxRules completes the rules in the review queue.
+func completeInboxRules(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c, err := hoist.New(hoist.Options{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	q, err := c.LoadQueue()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	list := inboxList(cmd)
+	var rules []string
+	for _, e := range q.Entries {
+		if e.List == list {
+			rules = append(rules, e.Rule)
+		}
+	}
+	return rules, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	inboxCmd.Flags().Bool("dismissed", false, "list dismissed rules instead")
+	for _, c := range []*cobra.Command{inboxAddCmd, inboxDismissCmd} {
+		c.Flags().Bool("ask", false, "ask rules rather than allow rules")
+		c.Flags().Bool("deny", false, "deny rules rather than allow rules")
+		c.MarkFlagsMutuallyExclusive("ask", "deny")
+	}
+	inboxCmd.AddCommand(inboxStepCmd, inboxAddCmd, inboxDismissCmd)
+	rootCmd.AddCommand(inboxCmd)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+// includeKinds are the values accepted by --include.
+var includeKinds = []string{"mcp", "dirs", "mode", "env"}
+
+// extras holds the --include selection and the env flags that go with it.
+type extras struct {
+	include map[string]bool
+
+	reveal       bool // print env values
+	allowSecrets bool // hoist env values that look like secrets
+}
+
+// addIncludeFlags registers --include for the given kinds (all when none are
+// given), plus the env flags when env is one of them.
+func addIncludeFlags(c *cobra.Command, kinds ...string) {
+	if len(kinds) == 0 {
+		kinds = includeKinds
+	}
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	c.Annotations["include"] = strings.Join(kinds, ",")
+	c.Flags().StringSlice("include", nil, "also hoist other settings ("+strings.Join(kinds, ", ")+")")
+	c.RegisterFlagCompletionFunc("include", completeList(kinds))
+	for _, k := range kinds {
+		if k == "env" {
+			c.Flags().Bool("reveal", false, "print env values, which are masked otherwise (diff masks only secret-looking ones)")
+			c.Flags().Bool("allow-secrets", false, "hoist env values that look like secrets")
+		}
+	}
+}
+
+// loadExtras reads the kinds selected with --include and turns them on in opts.
+func loadExtras(cmd *cobra.Command, opts *hoist.PlanOptions) (extras, error) {
+	values, _ := cmd.Flags().GetStringSlice("include")
+	kinds := strings.Split(cmd.Annotations["include"], ",")
+	e := extras{include: make(map[string]bool, len(values))}
+	for _, v := range values {
+		known := false
+		for _, k := range kinds {
+			known = known || v == k
+		}
+		if !known {
+			return extras{}, fmt.Errorf("unknown --include value %q (want: %s)", v, strings.Join(kinds, ", "))
+		}
+		e.include[v] = true
+	}
+	e.reveal, _ = cmd.Flags().GetBool("reveal")
+	e.allowSecrets, _ = cmd.Flags().GetBool("allow-secrets")
+
+	opts.MCP = e.include["mcp"]
+	opts.Dirs = e.include["dirs"]
+	opts.Mode = e.include["mode"]
+	opts.Env = e.include["env"]
+	return e, nil
+}
+
+// envValue returns v for printing: masked, unless --reveal is set. Values
+// that don't look like secrets are hidden too, since a key or token in an
+// unexpected format still shouldn't end up on screen or in a terminal log.
+func (e extras) envValue(v string) string {
+	if e.reveal {
+		return v
+	}
+	return hoist.MaskValue(v)
+}
+
+// resolveEnv drops secret-looking values unless --allow-secrets is set and
+// settles conflicts: with ask set the user picks a value, otherwise the
+// user's value is kept.
+func (e extras) resolveEnv(p *hoist.Plan, ask bool) {
+	var kept []hoist.EnvChange
+	for _, c := range p.Env {
+		if c.Secret != "" && !e.allowSecrets {
+			continue
+		}
+		if c.Conflict {
+			if !ask {
+				continue
+			}
+			fmt.Printf("\nenv %s: keep user value %q or take project value %q? [u/p] ",
+				c.Key, e.envValue(c.UserValue), e.envValue(c.Value))
+			var answer string
+			fmt.Scanln(&answer)
+			if answer != "p" && answer != "P" {
+				continue
+			}
+		}
+		kept = append(kept, c)
+	}
+	p.Env = kept
+}
+
+// print lists the changes, flagging ones that won't be applied as-is.
+func (e extras) print(p *hoist.Plan, newAllow []string) {
+	if e.include["mcp"] {
+		printMCP(p.MCP, p.User, newAllow)
+	}
+	if len(p.Dirs) > 0 {
+		fmt.Printf("\nNew additional directories (%d):\n", len(p.Dirs))
+		for _, d := range p.Dirs {
+			if d.Missing {
+				fmt.Printf("  ! %s  (doesn't exist, skipped)\n", d.Path)
+				continue
+			}
+			fmt.Printf("  + %s\n", d.Path)
+		}
+	}
+	if len(p.Env) > 0 {
+		fmt.Printf("\nNew env variables (%d):\n", len(p.Env))
+		for _, c := range p.Env {
+			line := c.Key + "=" + e.envValue(c.Value)
+			switch {
+			case c.Secret != "" && !e.allowSecrets:
+				fmt.Printf("  ! %s  (%s; skipped, use --allow-secrets)\n", line, c.Secret)
+			case c.Conflict:
+				fmt.Printf("  ~ %s  (user config has %s)\n", line, e.envValue(c.UserValue))
+			default:
+				fmt.Printf("  + %s\n", line)
+			}
+		}
+	}
+	if m := p.Mode; m != nil {
+		if m.Conflict() {
+			fmt.Printf("\n%s: project has %q, user config has %q\n", m.Key, m.To, m.From)
+		} else {
+			fmt.Printf("\n%s: %q\n", m.Key, m.To)
+		}
+	}
+}
+
+// printMCP lists MCP server enablements next to the rules that depend on them,
+// and warns about rules whose server would still be disabled at user level.
+func printMCP(c hoist.MCPChanges, user hoist.Settings, newAllow []string) {
+	byServer := hoist.RulesByServer(newAllow)
+
+	if len(c.Enable) > 0 {
+		fmt.Printf("\nMCP servers to enable (%d):\n", len(c.Enable))
+		for _, server := range c.Enable {
+			fmt.Printf("  + %s\n", server)
+			for _, rule := range byServer[server] {
+				fmt.Printf("      needed by %s\n", rule)
+			}
+		}
+	}
+	if len(c.Disable) > 0 {
+		fmt.Printf("\nMCP servers to disable (%d):\n", len(c.Disable))
+		for _, server := range c.Disable {
+			fmt.Printf("  + %s\n", server)
+		}
+	}
+	if c.EnableAll != nil {
+		fmt.Printf("\nenableAllProjectMcpServers: %v\n", *c.EnableAll)
+	}
+	if c.EnableAllConflict {
+		fmt.Printf("\nenableAllProjectMcpServers differs from your user config (%v); leaving it alone\n",
+			*user.EnableAllProjectMcpServers)
+	}
+
+	merged := hoist.MergeMCP(user, c)
+	servers := make([]string, 0, len(byServer))
+	for server := range byServer {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+	for _, server := range servers {
+		if !hoist.MCPServerEnabled(merged, server) {
+			fmt.Printf("\n  ! server %q isn't enabled in your user config; its rules only help where a project enables it\n", server)
+		}
+	}
+}
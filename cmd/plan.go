@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Compute what add would change and save it for review",
+	Long: `Computes what add would change, without writing anything, and with -o saves
+it to a plan file that apply writes later:
+
+  claude-hoist plan -o hoist.plan
+  claude-hoist apply hoist.plan
+
+The plan file is JSON. It records the project and user settings files with
+the SHA-256 of their content, and the exact rules and settings that will be
+added. apply refuses a plan when either file has changed since, so what is
+written is exactly what was reviewed.
+
+plan never prompts: conflicting env values keep your value, and a differing
+defaultMode is only replaced with --replace-mode.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, plan, extra := loadPlan(cmd)
+		printBlocked(os.Stdout, plan.Blocked)
+		newAllow := plan.Allowed()
+
+		if plan.Empty() {
+			fmt.Println("nothing to do — all project permissions already exist in user config")
+			return
+		}
+
+		printRules(newAllow, plan.Ask, plan.Deny)
+		extra.print(plan, newAllow)
+		if m := plan.Mode; m != nil && m.Conflict() {
+			plan.ReplaceMode, _ = cmd.Flags().GetBool("replace-mode")
+			if !plan.ReplaceMode {
+				fmt.Printf("keeping your %s %q; use --replace-mode to replace it\n", m.Key, m.From)
+			}
+		}
+		for _, c := range plan.Env {
+			if c.Conflict {
+				fmt.Println("keeping your values for conflicting env variables")
+				break
+			}
+		}
+		extra.resolveEnv(plan, false)
+
+		out, _ := cmd.Flags().GetString("out")
+		if out == "" {
+			fmt.Println("\nnot saved; use -o to save the plan for apply")
+			return
+		}
+		data, err := json.MarshalIndent(plan.File(), "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := hoist.WriteFileAtomic(out, append(data, '\n'), 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nsaved plan to %s; apply it with: claude-hoist apply %s\n", out, out)
+	},
+}
+
+func init() {
+	addFilterFlags(planCmd)
+	planCmd.Flags().StringP("out", "o", "", "save the plan to this file")
+	planCmd.Flags().Bool("replace-mode", false, "replace a differing defaultMode in your user config")
+	addIncludeFlags(planCmd)
+	rootCmd.AddCommand(planCmd)
+}
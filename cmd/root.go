@@ -17,6 +17,13 @@ This lets you promote project-specific permission decisions to apply globally,
 so you don't have to re-approve the same tools across projects.`,
 }
 
+// keepComments is set by --keep-comments.
+var keepComments bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&keepComments, "keep-comments", false, "keep // and /* */ comments when rewriting settings files")
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/jeffrydegrande/claude-hoist/hoist"
 	"github.com/spf13/cobra"
@@ -12,36 +11,68 @@ var showCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show project permissions that aren't in your user config yet",
 	Run: func(cmd *cobra.Command, args []string) {
-		_, _, _, newAllow, newDeny, err := hoist.LoadBoth()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			os.Exit(1)
-		}
+		_, plan, extra := loadPlan(cmd)
+		newAllow, blocked := plan.Allow, plan.Blocked
 
-		if len(newAllow) == 0 && len(newDeny) == 0 {
+		if plan.Empty() && len(blocked) == 0 {
 			fmt.Println("nothing new — all project permissions already exist in user config")
+			printManaged(plan.Managed, plan.ManagedPath)
 			return
 		}
 
 		if len(newAllow) > 0 {
 			fmt.Printf("New allow rules (%d):\n", len(newAllow))
 			for _, rule := range newAllow {
+				if by, ok := blocked[rule]; ok {
+					fmt.Printf("  ! %s  (ineffective: managed deny %s)\n", rule, by)
+					continue
+				}
 				fmt.Printf("  + %s\n", rule)
 			}
 		}
 
-		if len(newDeny) > 0 {
+		if len(plan.Ask) > 0 {
 			if len(newAllow) > 0 {
 				fmt.Println()
 			}
-			fmt.Printf("New deny rules (%d):\n", len(newDeny))
-			for _, rule := range newDeny {
+			fmt.Printf("New ask rules (%d):\n", len(plan.Ask))
+			for _, rule := range plan.Ask {
+				fmt.Printf("  + %s\n", rule)
+			}
+		}
+
+		if len(plan.Deny) > 0 {
+			if len(newAllow) > 0 || len(plan.Ask) > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("New deny rules (%d):\n", len(plan.Deny))
+			for _, rule := range plan.Deny {
 				fmt.Printf("  + %s\n", rule)
 			}
 		}
+
+		extra.print(plan, newAllow)
+
+		printManaged(plan.Managed, plan.ManagedPath)
 	},
 }
 
+// printManaged lists managed rules, which apply everywhere and can't be changed.
+func printManaged(managed hoist.Settings, path string) {
+	p := managed.Permissions
+	if len(p.Allow) == 0 && len(p.Ask) == 0 && len(p.Deny) == 0 {
+		return
+	}
+	fmt.Printf("\nManaged rules (read-only, %s):\n", path)
+	for _, list := range hoist.Lists {
+		for _, rule := range p.Rules(list) {
+			fmt.Printf("  %-5s %s\n", list, rule)
+		}
+	}
+}
+
 func init() {
+	addFilterFlags(showCmd)
+	addIncludeFlags(showCmd)
 	rootCmd.AddCommand(showCmd)
 }
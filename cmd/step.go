@@ -12,18 +12,16 @@ var stepCmd = &cobra.Command{
 	Use:   "step",
 	Short: "Step through each new permission one by one",
 	Run: func(cmd *cobra.Command, args []string) {
-		_, user, userPath, newAllow, newDeny, err := hoist.LoadBoth()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			os.Exit(1)
-		}
+		c, plan, extra := loadPlan(cmd)
+		printBlocked(os.Stdout, plan.Blocked)
+		newAllow, newDeny := plan.Allowed(), plan.Deny
 
-		if len(newAllow) == 0 && len(newDeny) == 0 {
+		if plan.Empty() {
 			fmt.Println("nothing new — all project permissions already exist in user config")
 			return
 		}
 
-		var acceptedAllow, acceptedDeny []string
+		var acceptedAllow, acceptedAsk, acceptedDeny []string
 
 		if len(newAllow) > 0 {
 			fmt.Printf("Allow rules (%d new):\n\n", len(newAllow))
@@ -31,25 +29,53 @@ var stepCmd = &cobra.Command{
 			acceptedAllow = append(acceptedAllow, accepted...)
 		}
 
+		if len(plan.Ask) > 0 {
+			fmt.Printf("\nAsk rules (%d new):\n\n", len(plan.Ask))
+			acceptedAsk = stepThrough(plan.Ask)
+		}
+
 		if len(newDeny) > 0 {
 			fmt.Printf("\nDeny rules (%d new):\n\n", len(newDeny))
 			accepted := stepThrough(newDeny)
 			acceptedDeny = append(acceptedDeny, accepted...)
 		}
 
-		if len(acceptedAllow) == 0 && len(acceptedDeny) == 0 {
+		var acceptedEnv []hoist.EnvChange
+		if len(plan.Env) > 0 {
+			labels := make([]string, 0, len(plan.Env))
+			byLabel := make(map[string]hoist.EnvChange, len(plan.Env))
+			for _, c := range plan.Env {
+				label := c.Key + "=" + extra.envValue(c.Value)
+				if c.Secret != "" && !extra.allowSecrets {
+					fmt.Printf("  ! skipping %s (%s; use --allow-secrets)\n", label, c.Secret)
+					continue
+				}
+				if c.Conflict {
+					label += "  (replaces " + extra.envValue(c.UserValue) + ")"
+				}
+				labels = append(labels, label)
+				byLabel[label] = c
+			}
+			fmt.Printf("\nEnv variables (%d new):\n\n", len(labels))
+			for _, label := range stepThrough(labels) {
+				acceptedEnv = append(acceptedEnv, byLabel[label])
+			}
+		}
+
+		if len(acceptedAllow) == 0 && len(acceptedAsk) == 0 && len(acceptedDeny) == 0 && len(acceptedEnv) == 0 {
 			fmt.Println("\nnothing selected")
 			return
 		}
 
-		merged := hoist.Merge(user, acceptedAllow, acceptedDeny)
-		if err := hoist.WriteSettings(userPath, merged); err != nil {
-			fmt.Fprintf(os.Stderr, "error writing: %v\n", err)
-			os.Exit(1)
-		}
+		plan.Allow, plan.Ask, plan.Deny, plan.Env = acceptedAllow, acceptedAsk, acceptedDeny, acceptedEnv
+		writePlan(c, plan)
 
-		total := len(acceptedAllow) + len(acceptedDeny)
-		fmt.Printf("\ndone — added %d rule(s) to %s\n", total, userPath)
+		total := len(acceptedAllow) + len(acceptedAsk) + len(acceptedDeny)
+		if len(acceptedEnv) > 0 {
+			fmt.Printf("\ndone — added %d rule(s) and %d env variable(s) to %s\n", total, len(acceptedEnv), plan.UserPath)
+			return
+		}
+		fmt.Printf("\ndone — added %d rule(s) to %s\n", total, plan.UserPath)
 	},
 }
 
@@ -57,8 +83,18 @@ var stepCmd = &cobra.Command{
 // y = accept, n = skip, q = quit (skip remaining).
 func stepThrough(rules []string) []string {
 	var accepted []string
-	for i, rule := range rules {
-		fmt.Printf("  [%d/%d] %s\n", i+1, len(rules), rule)
+	for _, i := range stepIndexes(rules) {
+		accepted = append(accepted, rules[i])
+	}
+	return accepted
+}
+
+// stepIndexes prompts for each label like stepThrough, and returns the
+// indexes of the accepted ones, for items whose labels may repeat.
+func stepIndexes(labels []string) []int {
+	var accepted []int
+	for i, label := range labels {
+		fmt.Printf("  [%d/%d] %s\n", i+1, len(labels), label)
 		fmt.Print("  add? [y/n/q] ")
 
 		var answer string
@@ -66,7 +102,7 @@ func stepThrough(rules []string) []string {
 
 		switch answer {
 		case "y", "Y":
-			accepted = append(accepted, rule)
+			accepted = append(accepted, i)
 		case "q", "Q":
 			fmt.Println("  skipping remaining")
 			return accepted
@@ -78,5 +114,7 @@ func stepThrough(rules []string) []string {
 }
 
 func init() {
+	addFilterFlags(stepCmd)
+	addIncludeFlags(stepCmd, "env")
 	rootCmd.AddCommand(stepCmd)
 }
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// addColorFlag registers --color; a bare --color means always.
+func addColorFlag(c *cobra.Command) {
+	c.Flags().String("color", "auto", "color output: auto, always or never (NO_COLOR disables auto)")
+	c.Flags().Lookup("color").NoOptDefVal = "always"
+	c.RegisterFlagCompletionFunc("color", cobra.FixedCompletions([]string{"auto", "always", "never"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// useColor resolves a --color value for output written to f. In auto mode
+// color is used on terminals unless NO_COLOR is set or TERM is dumb.
+func useColor(mode string, f *os.File) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto", "":
+		return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb" && isTerminal(f), nil
+	}
+	return false, fmt.Errorf("invalid --color value %q (want auto, always or never)", mode)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth returns $COLUMNS, or the width of the terminal f is
+// attached to, or 80.
+func terminalWidth(f *os.File) int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if n := ttyWidth(f); n > 0 {
+		return n
+	}
+	return 80
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package cmd
+
+import "os"
+
+// ttyWidth is not supported on this platform; callers fall back to 80 columns.
+func ttyWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestUseColor(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	t.Setenv("NO_COLOR", "1")
+	for mode, want := range map[string]bool{"always": true, "never": false, "auto": false} {
+		if got, err := useColor(mode, f); err != nil || got != want {
+			t.Errorf("useColor(%q) = %v, %v; want %v", mode, got, err, want)
+		}
+	}
+	if _, err := useColor("sometimes", f); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [path...]",
+	Short: "Check settings files for unknown keys, wrong types and bad rules",
+	Long: `Checks each settings file against the settings keys and value types Claude
+Code knows about, and parses every permission rule. Unknown keys are reported
+with a suggestion when they look like a typo of a known key.
+
+Without arguments, checks the project and user settings files that exist.
+Exits non-zero if any file has errors; warnings alone don't fail.`,
+	ValidArgsFunction: completeJSONFiles,
+	Run: func(cmd *cobra.Command, args []string) {
+		paths := args
+		if len(paths) == 0 {
+			var err error
+			paths, err = defaultValidatePaths()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(paths) == 0 {
+				fmt.Println("No settings files found.")
+				return
+			}
+		}
+
+		failed := false
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				failed = true
+				continue
+			}
+
+			if reportIssues(os.Stdout, path, data) {
+				failed = true
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// reportIssues prints validation problems in data to w, reported against
+// path, and returns whether any of them is an error. Syntax errors go to stderr.
+func reportIssues(w io.Writer, path string, data []byte) bool {
+	issues, err := hoist.Validate(data)
+	if err != nil {
+		var syn *hoist.SyntaxError
+		if errors.As(err, &syn) {
+			syn.Path = path
+		}
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return true
+	}
+	failed := false
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s:%s\n", path, issue)
+		if issue.Error {
+			failed = true
+		}
+	}
+	return failed
+}
+
+// reportSyntax prints a syntax error in data, reported against path, and
+// returns whether there was one.
+func reportSyntax(path string, data []byte) bool {
+	var v any
+	err := hoist.UnmarshalJSONC(data, &v)
+	if err == nil {
+		return false
+	}
+	var syn *hoist.SyntaxError
+	if errors.As(err, &syn) {
+		syn.Path = path
+	}
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	return true
+}
+
+// defaultValidatePaths returns the project and user settings files that exist.
+func defaultValidatePaths() ([]string, error) {
+	c, err := hoist.New(hoist.Options{})
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, scope := range []hoist.Scope{hoist.ScopeProjectLocal, hoist.ScopeProjectShared, hoist.ScopeUserLocal, hoist.ScopeUserShared} {
+		if p := c.Path(scope); c.Exists(p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jeffrydegrande/claude-hoist/hoist"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [project-dir...]",
+	Short: "Hoist new approvals as they happen",
+	Long: `Watches the .claude/settings.local.json of one or more projects (the current
+directory when none are given) and runs add -y whenever one changes, so rules
+you approve in Claude Code reach your user config right away.
+
+The filter flags (--tool, --match, --exclude, ...) and --include select what
+is hoisted, as they do for add. Conflicting env values and a differing
+defaultMode are never replaced, and secret-looking env values are skipped
+unless --allow-secrets is set.
+
+With --queue, new rules are only added to the review queue in
+~/.claude/hoist-queue.json, to be reviewed later with inbox step.
+
+Every project is also handled once when watch starts, so rules approved while
+it wasn't running are hoisted, or queued, right away.
+
+Every hoisted or queued rule is logged with a timestamp to --log. Writes that
+come in quick succession are handled together once --debounce has passed
+without another. Changes are picked up with inotify on Linux and by polling
+every --interval elsewhere or with --poll.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+		w := &hoister{out: os.Stdout, opts: hoist.PlanOptions{Rules: true, SkipDismissed: true}, projects: map[string]*watchedProject{}}
+		var err error
+		if w.opts.Filter, err = loadFilter(cmd); err == nil {
+			w.extra, err = loadExtras(cmd, &w.opts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		w.queue, _ = cmd.Flags().GetBool("queue")
+		// --interval is checked even without --poll, since watch falls back
+		// to polling when inotify isn't available.
+		interval, _ := cmd.Flags().GetDuration("interval")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+		if interval <= 0 {
+			fmt.Fprintf(os.Stderr, "error: --interval must be positive, got %v\n", interval)
+			os.Exit(1)
+		}
+		if debounce <= 0 {
+			fmt.Fprintf(os.Stderr, "error: --debounce must be positive, got %v\n", debounce)
+			os.Exit(1)
+		}
+
+		var paths []string
+		for _, arg := range args {
+			dir, err := filepath.Abs(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			c, err := hoist.New(hoist.Options{ProjectDir: dir, KeepComments: keepComments})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			path := c.Path(hoist.ScopeProjectLocal)
+			if _, err := os.Stat(filepath.Dir(path)); err != nil {
+				fmt.Fprintf(os.Stderr, "error: no .claude directory in %s\n", dir)
+				os.Exit(1)
+			}
+			if w.projects[path] == nil {
+				w.projects[path] = &watchedProject{c: c}
+				paths = append(paths, path)
+			}
+		}
+
+		logFile, logPath, err := openLog(cmd, w.projects[paths[0]].c)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		defer logFile.Close()
+		w.log = logFile
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		changed := make(chan string, len(paths))
+		poll, _ := cmd.Flags().GetBool("poll")
+		how := "inotify"
+		if !poll {
+			if err := notifyFiles(ctx, paths, changed); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v; polling instead\n", err)
+				poll = true
+			}
+		}
+		if poll {
+			go pollFiles(ctx, paths, interval, changed)
+			how = "polling every " + interval.String()
+		}
+		fmt.Printf("watching %d project(s) (%s); logging to %s\n", len(paths), how, logPath)
+
+		for _, path := range paths {
+			w.hoist(path)
+		}
+
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		pending := map[string]bool{}
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Println("stopped")
+				return
+			case path := <-changed:
+				pending[path] = true
+				timer.Reset(debounce)
+			case <-timer.C:
+				for path := range pending {
+					w.hoist(path)
+				}
+				clear(pending)
+			}
+		}
+	},
+}
+
+// hoister runs the add pipeline, or queues rules for review, without
+// prompting, for watch and hook run.
+type hoister struct {
+	opts     hoist.PlanOptions
+	extra    extras
+	queue    bool      // only queue rules for review
+	out      io.Writer // where log lines are printed, besides log
+	log      io.Writer
+	note     string                     // appended to every log line
+	projects map[string]*watchedProject // by settings file
+}
+
+type watchedProject struct {
+	c *hoist.Client
+}
+
+// logf prints a line and appends it to the log with a timestamp.
+func (w *hoister) logf(format string, args ...any) {
+	line := fmt.Sprintf(format, args...) + w.note
+	fmt.Fprintln(w.out, line)
+	fmt.Fprintf(w.log, "%s %s\n", time.Now().Format(time.RFC3339), line)
+}
+
+// hoist runs the add pipeline for the project whose settings file is path,
+// or queues its new rules with --queue.
+func (w *hoister) hoist(path string) {
+	p := w.projects[path]
+	dir := p.c.ProjectDir()
+	if !p.c.Exists(path) {
+		return // not created yet, or between a delete and a rewrite
+	}
+	plan, err := p.c.Plan(w.opts)
+	if err != nil {
+		w.logf("error: %v", err)
+		return
+	}
+	if w.queue {
+		w.enqueue(p, plan)
+		return
+	}
+
+	w.extra.resolveEnv(plan, false)
+	if plan.Empty() {
+		return
+	}
+	rebased, err := p.c.Apply(plan)
+	if err != nil {
+		w.logf("error: writing %s: %v", plan.UserPath, err)
+		return
+	}
+	if rebased {
+		w.logf("note: %s changed while hoisting; merged into its current content", plan.UserPath)
+	}
+	for _, rule := range plan.Allowed() {
+		w.logf("hoisted allow %s from %s", rule, dir)
+	}
+	for _, rule := range plan.Ask {
+		w.logf("hoisted ask %s from %s", rule, dir)
+	}
+	for _, rule := range plan.Deny {
+		w.logf("hoisted deny %s from %s", rule, dir)
+	}
+	for _, server := range plan.MCP.Enable {
+		w.logf("hoisted MCP server %s from %s", server, dir)
+	}
+	for _, server := range plan.MCP.Disable {
+		w.logf("hoisted disabled MCP server %s from %s", server, dir)
+	}
+	if v := plan.MCP.EnableAll; v != nil {
+		w.logf("hoisted enableAllProjectMcpServers %v from %s", *v, dir)
+	}
+	for _, d := range plan.Dirs {
+		if !d.Missing {
+			w.logf("hoisted additional directory %s from %s", d.Path, dir)
+		}
+	}
+	if m := plan.Mode; m != nil && !m.Conflict() {
+		w.logf("hoisted %s %s from %s", m.Key, m.To, dir)
+	}
+	for _, c := range plan.Env {
+		w.logf("hoisted env %s from %s", c.Key, dir)
+	}
+	for _, h := range plan.Hooks {
+		w.logf("hoisted hook %s from %s", h, dir)
+	}
+}
+
+// enqueue adds the project's unhoisted rules to the review queue, except
+// dismissed ones. Rules already queued are counted again only the first
+// time they turn up in another project, not each time a project is looked
+// at.
+func (w *hoister) enqueue(p *watchedProject, plan *hoist.Plan) {
+	dir := p.c.ProjectDir()
+	candidates := map[hoist.List][]string{hoist.ListAllow: plan.Allowed(), hoist.ListAsk: plan.Ask, hoist.ListDeny: plan.Deny}
+	now := time.Now()
+	err := p.c.UpdateQueue(func(q *hoist.Queue) error {
+		for _, l := range hoist.Lists {
+			for _, rule := range candidates[l] {
+				if i := q.Find(l, rule); q.IsDismissed(l, rule) || i >= 0 && q.Entries[i].SeenIn(dir) {
+					continue
+				}
+				if q.Add(l, rule, dir, now) {
+					w.logf("queued %s %s from %s", l, rule, dir)
+				} else {
+					w.logf("seen %s %s again in %s", l, rule, dir)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		w.logf("error: updating the review queue: %v", err)
+	}
+}
+
+// openLog opens the file named by --log for appending, by default
+// ~/.claude/claude-hoist.log.
+func openLog(cmd *cobra.Command, c *hoist.Client) (*os.File, string, error) {
+	path, _ := cmd.Flags().GetString("log")
+	if path == "" {
+		path = filepath.Join(c.Home(), ".claude", "claude-hoist.log")
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	return f, path, err
+}
+
+// pollFiles sends a path on changed whenever its modification time or size
+// changes, checking every interval until ctx is done.
+func pollFiles(ctx context.Context, paths []string, interval time.Duration, changed chan<- string) {
+	type stamp struct {
+		mod  time.Time
+		size int64
+	}
+	stampOf := func(path string) stamp {
+		info, err := os.Stat(path)
+		if err != nil {
+			return stamp{}
+		}
+		return stamp{info.ModTime(), info.Size()}
+	}
+	last := make(map[string]stamp, len(paths))
+	for _, path := range paths {
+		last[path] = stampOf(path)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, path := range paths {
+			s := stampOf(path)
+			if s == last[path] {
+				continue
+			}
+			last[path] = s
+			select {
+			case changed <- path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func init() {
+	addFilterFlags(watchCmd)
+	addIncludeFlags(watchCmd)
+	watchCmd.Flags().Bool("queue", false, "only add new rules to the review queue")
+	watchCmd.Flags().String("log", "", "file to log hoisted and queued rules to (default ~/.claude/claude-hoist.log)")
+	watchCmd.Flags().Duration("debounce", 500*time.Millisecond, "wait this long after a write for more before hoisting")
+	watchCmd.Flags().Bool("poll", false, "poll for changes instead of using file notifications")
+	watchCmd.Flags().Duration("interval", 2*time.Second, "how often to poll")
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,67 @@
+//go:build linux
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// notifyFiles sends a path on changed whenever its file is written or
+// replaced, until ctx is done. It watches the parent directories, since
+// settings files are usually replaced by rename.
+func notifyFiles(ctx context.Context, paths []string, changed chan<- string) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return fmt.Errorf("inotify: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), "inotify")
+
+	dirs := map[int32]string{}
+	watched := map[string]bool{}
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		wd, err := syscall.InotifyAddWatch(fd, dir, syscall.IN_CLOSE_WRITE|syscall.IN_MOVED_TO)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("inotify: watching %s: %w", dir, err)
+		}
+		dirs[int32(wd)] = dir
+		watched[path] = true
+	}
+
+	go func() {
+		<-ctx.Done()
+		f.Close()
+	}()
+	go func() {
+		buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+		for {
+			n, err := f.Read(buf)
+			if err != nil {
+				return
+			}
+			for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+				ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+				start := off + syscall.SizeofInotifyEvent
+				off = start + int(ev.Len)
+				name := bytes.TrimRight(buf[start:off], "\x00")
+				path := filepath.Join(dirs[ev.Wd], string(name))
+				if !watched[path] {
+					continue
+				}
+				select {
+				case changed <- path:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package cmd
+
+import (
+	"context"
+	"errors"
+)
+
+// notifyFiles is not supported on this platform; watch polls instead.
+func notifyFiles(ctx context.Context, paths []string, changed chan<- string) error {
+	return errors.New("file notifications aren't supported on this platform")
+}
@@ -0,0 +1,176 @@
+package hoist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Options configures a Client. The zero value works on the host file system
+// for the current directory and user.
+type Options struct {
+	FS           FS     // defaults to OSFS
+	ProjectDir   string // defaults to the working directory
+	Home         string // defaults to the user's home directory
+	ManagedPath  string // defaults to ManagedSettingsPath()
+	KeepComments bool   // keep // and /* */ comments when rewriting files
+}
+
+// Client reads and writes the settings files of one project and one user.
+// It touches the file system only through Options.FS, so tests and tools
+// embedding claude-hoist can point it anywhere.
+type Client struct {
+	opts Options
+}
+
+// New returns a Client, filling in the defaults for unset options.
+func New(opts Options) (*Client, error) {
+	if opts.FS == nil {
+		opts.FS = OSFS{}
+	}
+	if opts.ProjectDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		opts.ProjectDir = cwd
+	}
+	if opts.Home == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		opts.Home = home
+	}
+	if opts.ManagedPath == "" {
+		opts.ManagedPath = ManagedSettingsPath()
+	}
+	return &Client{opts: opts}, nil
+}
+
+// FS returns the file system the client works on.
+func (c *Client) FS() FS { return c.opts.FS }
+
+// ProjectDir returns the project directory project scopes are resolved against.
+func (c *Client) ProjectDir() string { return c.opts.ProjectDir }
+
+// Home returns the home directory user scopes are resolved against.
+func (c *Client) Home() string { return c.opts.Home }
+
+// Path returns the settings file for a scope.
+func (c *Client) Path(scope Scope) string {
+	p, _ := scopePath(scope, c.opts.ProjectDir, c.opts.Home, c.opts.ManagedPath)
+	return p
+}
+
+// ClaudeJSONPath returns ~/.claude.json.
+func (c *Client) ClaudeJSONPath() string {
+	return filepath.Join(c.opts.Home, ".claude.json")
+}
+
+// Exists reports whether path exists.
+func (c *Client) Exists(path string) bool {
+	_, err := c.opts.FS.Stat(path)
+	return err == nil
+}
+
+// ReadSettings reads a settings file. Comments and trailing commas are
+// tolerated; malformed input is reported as a *SyntaxError, and a missing
+// file as an error matching fs.ErrNotExist.
+func (c *Client) ReadSettings(path string) (Settings, error) {
+	data, err := c.opts.FS.ReadFile(path)
+	if err != nil {
+		return Settings{}, err
+	}
+	return parseSettings(path, data)
+}
+
+// LoadScopes reads every scope's settings file in precedence order.
+// Files that don't exist are skipped.
+func (c *Client) LoadScopes() ([]ScopeSettings, error) {
+	var result []ScopeSettings
+	for _, scope := range Scopes {
+		p := c.Path(scope)
+		s, err := c.ReadSettings(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s settings: %w", scope, err)
+		}
+		result = append(result, ScopeSettings{Scope: scope, Path: p, Settings: s})
+	}
+	return result, nil
+}
+
+// LoadManaged reads the managed settings file. Managed settings are read-only
+// to claude-hoist; a missing file yields empty settings.
+func (c *Client) LoadManaged() (Settings, string, error) {
+	p := c.opts.ManagedPath
+	s, err := c.ReadSettings(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Settings{}, p, nil
+	}
+	if err != nil {
+		return Settings{}, p, fmt.Errorf("reading managed settings: %w", err)
+	}
+	return s, p, nil
+}
+
+// Lock takes the file system's advisory lock on path, if it has one. Hold
+// it from reading a file to writing it back so concurrent claude-hoist runs
+// don't lose each other's changes.
+func (c *Client) Lock(path string) (unlock func(), err error) {
+	if l, ok := c.opts.FS.(Locker); ok {
+		return l.Lock(path)
+	}
+	return func() {}, nil
+}
+
+// Encode returns the current content of path and the content WriteSettings
+// would replace it with, as EncodeSettings does.
+func (c *Client) Encode(path string, s Settings) (before, after []byte, err error) {
+	before, err = c.opts.FS.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	after, err = encodeSettings(before, s, c.opts.KeepComments)
+	return before, after, err
+}
+
+// WriteSettings writes s to path, editing an existing file in place so
+// untouched lines, key order and indentation stay as they were.
+func (c *Client) WriteSettings(path string, s Settings) error {
+	_, data, err := c.Encode(path, s)
+	if err != nil {
+		return err
+	}
+	return c.opts.FS.WriteFile(path, data, 0600)
+}
+
+// UpdateSettings reads the settings file at path, lets update change it and
+// writes it back in place, holding the file's lock throughout. A missing
+// file starts out empty; the file isn't written when nothing changed.
+func (c *Client) UpdateSettings(path string, update func(s *Settings) error) error {
+	unlock, err := c.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := c.ReadSettings(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := update(&s); err != nil {
+		return err
+	}
+	before, after, err := c.Encode(path, s)
+	if err != nil || bytes.Equal(before, after) {
+		return err
+	}
+	return c.opts.FS.WriteFile(path, after, 0600)
+}
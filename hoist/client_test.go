@@ -0,0 +1,247 @@
+package hoist
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, files map[string]string) (*Client, *MemFS) {
+	t.Helper()
+	mem := NewMemFS(files)
+	c, err := New(Options{FS: mem, ProjectDir: "/work/app", Home: "/home/me", ManagedPath: "/etc/managed.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, mem
+}
+
+func TestClientPaths(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	want := map[Scope]string{
+		ScopeManaged:       "/etc/managed.json",
+		ScopeProjectLocal:  filepath.Join("/work/app", ".claude", "settings.local.json"),
+		ScopeProjectShared: filepath.Join("/work/app", ".claude", "settings.json"),
+		ScopeUserLocal:     filepath.Join("/home/me", ".claude", "settings.local.json"),
+		ScopeUserShared:    filepath.Join("/home/me", ".claude", "settings.json"),
+	}
+	for scope, p := range want {
+		if got := c.Path(scope); got != p {
+			t.Errorf("%s: got %q, want %q", scope, got, p)
+		}
+	}
+	if got := c.ClaudeJSONPath(); got != filepath.Join("/home/me", ".claude.json") {
+		t.Errorf("claude.json: got %q", got)
+	}
+}
+
+func TestClientPlanAndApply(t *testing.T) {
+	c, mem := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{
+  "permissions": {
+    "allow": ["Bash(go test:*)", "Bash(curl:*)", "Read"],
+    "deny": ["Bash(rm:*)"],
+    "defaultMode": "acceptEdits",
+    "additionalDirectories": ["../lib", "../gone"]
+  },
+  "env": {"GOFLAGS": "-mod=mod"}
+}`,
+		"/work/lib/README":                     "",
+		"/home/me/.claude/settings.local.json": "{\n  // mine\n  \"permissions\": {\n    \"allow\": [\"Read\"]\n  }\n}\n",
+		"/etc/managed.json":                    `{"permissions": {"deny": ["Bash(curl:*)"]}}`,
+	})
+
+	plan, err := c.Plan(PlanOptions{Rules: true, Dirs: true, Mode: true, Env: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(plan.Allow, []string{"Bash(go test:*)", "Bash(curl:*)"}) {
+		t.Errorf("allow: got %v", plan.Allow)
+	}
+	if !reflect.DeepEqual(plan.Allowed(), []string{"Bash(go test:*)"}) || plan.Blocked["Bash(curl:*)"] != "Bash(curl:*)" {
+		t.Errorf("allowed %v, blocked %v", plan.Allowed(), plan.Blocked)
+	}
+	if len(plan.Dirs) != 2 || plan.Dirs[0].Missing || !plan.Dirs[1].Missing {
+		t.Errorf("dirs: got %+v", plan.Dirs)
+	}
+	if !(&Plan{Dirs: plan.Dirs[1:]}).Empty() {
+		t.Error("a plan with only missing directories must be empty")
+	}
+	if plan.Mode == nil || plan.Mode.To != "acceptEdits" || len(plan.Env) != 1 {
+		t.Errorf("mode %+v, env %+v", plan.Mode, plan.Env)
+	}
+
+	plan.Env = nil // callers may drop changes before applying
+	if _, err := c.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := mem.ReadFile("/home/me/.claude/settings.local.json")
+	want := `{
+  "permissions": {
+    "allow": ["Bash(go test:*)", "Read"],
+    "additionalDirectories": [
+      "/work/lib"
+    ],
+    "defaultMode": "acceptEdits",
+    "deny": [
+      "Bash(rm:*)"
+    ]
+  }
+}
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if files := mem.Files(); len(files) != 4 {
+		t.Errorf("files: %v", files)
+	}
+}
+
+func TestClientPlanWithoutUserConfig(t *testing.T) {
+	c, mem := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{"permissions": {"allow": ["Read"]}}`,
+	})
+	plan, err := c.Plan(PlanOptions{Rules: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.ReadSettings(plan.UserPath)
+	if err != nil || !reflect.DeepEqual(s.Permissions.Allow, []string{"Read"}) {
+		t.Errorf("got %+v, %v", s, err)
+	}
+	if info, _ := mem.Stat(plan.UserPath); info.Mode().Perm() != 0600 {
+		t.Errorf("mode: %v", info.Mode())
+	}
+}
+
+func TestClientPlanNoProject(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	_, err := c.Plan(PlanOptions{Rules: true})
+	if err == nil || !strings.Contains(err.Error(), "no .claude/settings.local.json in /work/app") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestClientLoadScopes(t *testing.T) {
+	c, _ := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.json": `{"permissions": {"allow": ["Read"]}}`,
+		"/home/me/.claude/settings.json":  `{"permissions": {"deny": ["WebFetch"]}}`,
+	})
+	scopes, err := c.LoadScopes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scopes) != 2 || scopes[0].Scope != ScopeProjectShared || scopes[1].Scope != ScopeUserShared {
+		t.Errorf("got %+v", scopes)
+	}
+}
+
+func TestMemFS(t *testing.T) {
+	m := &MemFS{}
+	if _, err := m.ReadFile("a/b.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: got %v", err)
+	}
+	m.WriteFile("a/b.json", []byte("x"), 0644)
+	m.WriteFile("a/./b.json", []byte("y"), 0600)
+	if data, _ := m.ReadFile("a/b.json"); string(data) != "y" {
+		t.Errorf("got %q", data)
+	}
+	if info, err := m.Stat("a/b.json"); err != nil || info.Mode().Perm() != 0644 || info.Size() != 1 {
+		t.Errorf("file: %v %v", info, err)
+	}
+	if info, err := m.Stat("a"); err != nil || !info.IsDir() {
+		t.Errorf("dir: %v %v", info, err)
+	}
+	if _, err := m.Stat("ab"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("prefix isn't a dir: %v", err)
+	}
+}
+
+func TestClientApplyRebases(t *testing.T) {
+	c, mem := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{
+  "permissions": {"allow": ["Bash(go test:*)"], "defaultMode": "acceptEdits"},
+  "env": {"A": "project", "B": "project"}
+}`,
+		"/home/me/.claude/settings.local.json": `{"permissions": {"allow": ["Read"]}}`,
+	})
+	plan, err := c.Plan(PlanOptions{Rules: true, Mode: true, Env: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Claude Code approves a prompt while the plan waits for confirmation.
+	mem.WriteFile(plan.UserPath, []byte(`{"permissions": {"allow": ["Read", "WebSearch"], "defaultMode": "plan"}, "env": {"A": "mine"}}`), 0600)
+
+	rebased, err := c.Apply(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rebased {
+		t.Error("concurrent change not detected")
+	}
+	s, _ := c.ReadSettings(plan.UserPath)
+	if !reflect.DeepEqual(s.Permissions.Allow, []string{"Bash(go test:*)", "Read", "WebSearch"}) {
+		t.Errorf("allow: got %v", s.Permissions.Allow)
+	}
+	if s.Permissions.DefaultMode != "plan" || !reflect.DeepEqual(s.Env, map[string]string{"A": "mine", "B": "project"}) {
+		t.Errorf("concurrent values must win: mode %q, env %v", s.Permissions.DefaultMode, s.Env)
+	}
+
+	if rebased, err := c.Apply(plan); err != nil || rebased {
+		t.Errorf("second apply: rebased %v, err %v", rebased, err)
+	}
+}
+
+func TestPlanAskRules(t *testing.T) {
+	c, mem := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{"permissions": {"allow": ["Read"], "ask": ["Bash(git push:*)", "WebFetch"]}}`,
+		"/home/me/.claude/settings.local.json":  `{"permissions": {"ask": ["WebFetch"]}}`,
+	})
+	plan, err := c.Plan(PlanOptions{Rules: true, Filter: Filter{Lists: []List{ListAsk}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Allow) != 0 || !reflect.DeepEqual(plan.Ask, []string{"Bash(git push:*)"}) || plan.Empty() {
+		t.Fatalf("allow %v, ask %v", plan.Allow, plan.Ask)
+	}
+
+	f := plan.File()
+	loaded, err := c.LoadPlan(&f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Apply(loaded); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := mem.ReadFile(plan.UserPath)
+	user, err := parseSettings(plan.UserPath, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(user.Permissions.Ask, []string{"Bash(git push:*)", "WebFetch"}) {
+		t.Errorf("ask: got %v", user.Permissions.Ask)
+	}
+}
+
+func TestPlanSharedHooks(t *testing.T) {
+	c, _ := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.json": `{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "notify"}]}]}}`,
+	})
+	if _, err := c.Plan(PlanOptions{Hooks: true}); err == nil {
+		t.Error("expected an error without settings.local.json")
+	}
+	plan, err := c.Plan(PlanOptions{Hooks: true, SharedHooks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Hooks) != 1 || plan.Hooks[0].Hook.Command != "notify" {
+		t.Errorf("hooks: got %+v", plan.Hooks)
+	}
+}
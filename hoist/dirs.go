@@ -0,0 +1,92 @@
+package hoist
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirChange is an additional directory the project grants that the user config lacks.
+type DirChange struct {
+	Dir     string // as written in the project settings
+	Path    string // normalized absolute path, written to the user config
+	Missing bool   // the directory doesn't exist on this machine
+}
+
+// NormalizeDir expands a leading ~ to home, resolves relative paths against
+// base and cleans the result.
+func NormalizeDir(dir, home, base string) string {
+	if dir == "~" {
+		dir = home
+	} else if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		dir = filepath.Join(home, rest)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(base, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+// DiffDirs returns the project's additional directories that aren't already
+// in the user config, comparing normalized paths. Project-relative entries
+// are resolved against projectDir, user-relative ones against home.
+func DiffDirs(project, user Settings, home, projectDir string) []DirChange {
+	return diffDirs(project, user, home, projectDir, os.Stat)
+}
+
+func diffDirs(project, user Settings, home, projectDir string, stat func(string) (fs.FileInfo, error)) []DirChange {
+	have := make(map[string]bool)
+	for _, d := range user.Permissions.AdditionalDirectories {
+		have[NormalizeDir(d, home, home)] = true
+	}
+
+	var result []DirChange
+	for _, d := range project.Permissions.AdditionalDirectories {
+		p := NormalizeDir(d, home, projectDir)
+		if have[p] {
+			continue
+		}
+		have[p] = true
+
+		info, err := stat(p)
+		result = append(result, DirChange{Dir: d, Path: p, Missing: err != nil || !info.IsDir()})
+	}
+	return result
+}
+
+// MergeDirs adds the directories that exist to the user config, keeping the
+// order of the ones it has. Missing directories are skipped.
+func MergeDirs(user Settings, dirs []DirChange) Settings {
+	var add []string
+	for _, d := range dirs {
+		if !d.Missing {
+			add = append(add, d.Path)
+		}
+	}
+	user.Permissions.AdditionalDirectories = mergeList(user.Permissions.AdditionalDirectories, add)
+	return user
+}
+
+// ScalarChange is a single-valued setting the project sets differently from
+// the user config. From is empty when the user config doesn't set it.
+type ScalarChange struct {
+	Key  string `json:"key"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Conflict reports whether applying the change would replace a user value.
+func (c ScalarChange) Conflict() bool {
+	return c.From != ""
+}
+
+// DiffDefaultMode returns the project's defaultMode when it differs from the
+// user's, or nil.
+func DiffDefaultMode(project, user Settings) *ScalarChange {
+	p, u := project.Permissions.DefaultMode, user.Permissions.DefaultMode
+	if p == "" || p == u {
+		return nil
+	}
+	return &ScalarChange{Key: "defaultMode", From: u, To: p}
+}
@@ -0,0 +1,71 @@
+package hoist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeDir(t *testing.T) {
+	tests := []struct {
+		dir, want string
+	}{
+		{"~", "/home/me"},
+		{"~/code/lib", "/home/me/code/lib"},
+		{"../shared/", "/work/shared"},
+		{"/opt//data/.", "/opt/data"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeDir(tt.dir, "/home/me", "/work/project"); got != tt.want {
+			t.Errorf("NormalizeDir(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestDiffDirs(t *testing.T) {
+	home := t.TempDir()
+	projectDir := filepath.Join(home, "project")
+	os.MkdirAll(filepath.Join(home, "shared"), 0755)
+	os.MkdirAll(filepath.Join(home, "docs"), 0755)
+
+	project := Settings{Permissions: Permissions{
+		AdditionalDirectories: []string{"../shared", "~/docs", "~/docs/", "/nonexistent/dir"},
+	}}
+	user := Settings{Permissions: Permissions{
+		AdditionalDirectories: []string{"docs"},
+	}}
+
+	got := DiffDirs(project, user, home, projectDir)
+	if len(got) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(got), got)
+	}
+	if got[0].Path != filepath.Join(home, "shared") || got[0].Missing {
+		t.Fatalf("got[0] = %+v", got[0])
+	}
+	if !got[1].Missing {
+		t.Fatalf("expected /nonexistent/dir to be missing: %+v", got[1])
+	}
+
+	merged := MergeDirs(user, got)
+	if len(merged.Permissions.AdditionalDirectories) != 2 {
+		t.Fatalf("merged: got %v", merged.Permissions.AdditionalDirectories)
+	}
+}
+
+func TestDiffDefaultMode(t *testing.T) {
+	project := Settings{Permissions: Permissions{DefaultMode: "acceptEdits"}}
+
+	if c := DiffDefaultMode(project, Settings{}); c == nil || c.Conflict() || c.To != "acceptEdits" {
+		t.Fatalf("unset user: got %+v", c)
+	}
+	user := Settings{Permissions: Permissions{DefaultMode: "plan"}}
+	if c := DiffDefaultMode(project, user); c == nil || !c.Conflict() || c.From != "plan" {
+		t.Fatalf("differing user: got %+v", c)
+	}
+	if c := DiffDefaultMode(project, project); c != nil {
+		t.Fatalf("equal: got %+v", c)
+	}
+	if c := DiffDefaultMode(Settings{}, user); c != nil {
+		t.Fatalf("unset project: got %+v", c)
+	}
+}
@@ -0,0 +1,80 @@
+package hoist
+
+import "fmt"
+
+// Status describes whether a rule takes part in permission decisions.
+type Status string
+
+const (
+	StatusEffective  Status = "effective"
+	StatusShadowed   Status = "shadowed"   // a rule in the same list already covers it
+	StatusOverridden Status = "overridden" // a stronger list covers it, so it never applies
+	StatusInvalid    Status = "invalid"    // the rule doesn't parse and is ignored
+)
+
+// EffectiveRule is one rule from one scope, annotated with its status.
+type EffectiveRule struct {
+	List   List   `json:"list"`
+	Rule   string `json:"rule"`
+	Scope  Scope  `json:"scope"`
+	Path   string `json:"path"`
+	Status Status `json:"status"`
+	By     string `json:"by,omitempty"` // the rule responsible for a shadowed or overridden status
+}
+
+// Effective combines the rules of every scope into the set Claude Code
+// evaluates, ordered deny, ask, allow and by scope precedence within a list.
+func Effective(scopes []ScopeSettings) []EffectiveRule {
+	type entry struct {
+		EffectiveRule
+		rank   int
+		parsed Rule
+		ok     bool
+	}
+
+	var entries []entry
+	for rank, list := range Lists {
+		for _, sc := range scopes {
+			for _, raw := range sc.Settings.Permissions.Rules(list) {
+				parsed, err := ParseRule(raw)
+				entries = append(entries, entry{
+					EffectiveRule: EffectiveRule{List: list, Rule: raw, Scope: sc.Scope, Path: sc.Path, Status: StatusEffective},
+					rank:          rank,
+					parsed:        parsed,
+					ok:            err == nil,
+				})
+			}
+		}
+	}
+
+	result := make([]EffectiveRule, len(entries))
+	for i, e := range entries {
+		result[i] = e.EffectiveRule
+		if !e.ok {
+			result[i].Status = StatusInvalid
+			continue
+		}
+		for j, o := range entries {
+			if i == j || !o.ok || !o.parsed.Covers(e.parsed) {
+				continue
+			}
+			if o.rank < e.rank {
+				result[i].Status = StatusOverridden
+				result[i].By = describe(o.EffectiveRule)
+				break
+			}
+			// A broader rule in the same list shadows this one; of two
+			// equivalent rules the first in precedence order wins.
+			if o.rank == e.rank && (j < i || !e.parsed.Covers(o.parsed)) {
+				result[i].Status = StatusShadowed
+				result[i].By = describe(o.EffectiveRule)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func describe(r EffectiveRule) string {
+	return fmt.Sprintf("%s %s [%s]", r.List, r.Rule, r.Scope)
+}
@@ -0,0 +1,38 @@
+package hoist
+
+import "testing"
+
+func TestEffective(t *testing.T) {
+	scopes := []ScopeSettings{
+		{Scope: ScopeProjectLocal, Path: "p", Settings: Settings{Permissions: Permissions{
+			Allow: []string{"Bash(git push:*)", "Bash(ls:*)", "Read"},
+		}}},
+		{Scope: ScopeUserLocal, Path: "u", Settings: Settings{Permissions: Permissions{
+			Allow: []string{"Bash(ls:*)", "Bash(go:*", "Read(./src/**)"},
+			Deny:  []string{"Bash(git:*)"},
+		}}},
+	}
+
+	got := Effective(scopes)
+	want := map[string]Status{
+		"deny Bash(git:*) [user]":          StatusEffective,
+		"allow Bash(git push:*) [project]": StatusOverridden,
+		"allow Bash(ls:*) [project]":       StatusEffective,
+		"allow Read [project]":             StatusEffective,
+		"allow Bash(ls:*) [user]":          StatusShadowed,
+		"allow Bash(go:* [user]":           StatusInvalid,
+		"allow Read(./src/**) [user]":      StatusShadowed,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rules, want %d: %+v", len(got), len(want), got)
+	}
+	if got[0].List != ListDeny {
+		t.Fatalf("first rule should be deny, got %+v", got[0])
+	}
+	for _, r := range got {
+		key := describe(r)
+		if r.Status != want[key] {
+			t.Errorf("%s: status %q, want %q (by %q)", key, r.Status, want[key], r.By)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package hoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+)
+
+// EnvChange is an environment variable the project sets that the user config
+// lacks or sets differently.
+type EnvChange struct {
+	Key       string
+	Value     string // the project's value
+	UserValue string // the user's current value, when Conflict is set
+	Conflict  bool
+	Secret    string // why the value looks like a secret, or ""
+}
+
+// DiffEnv compares env per key and returns the project's additions and
+// conflicting values, sorted by key.
+func DiffEnv(project, user Settings) []EnvChange {
+	keys := make([]string, 0, len(project.Env))
+	for k := range project.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var result []EnvChange
+	for _, k := range keys {
+		v := project.Env[k]
+		uv, ok := user.Env[k]
+		if ok && uv == v {
+			continue
+		}
+		result = append(result, EnvChange{
+			Key:       k,
+			Value:     v,
+			UserValue: uv,
+			Conflict:  ok,
+			Secret:    LooksSecret(k, v),
+		})
+	}
+	return result
+}
+
+// MergeEnv sets every change's project value in the user config. Callers
+// drop the changes they don't want, including unresolved conflicts, first.
+func MergeEnv(user Settings, changes []EnvChange) Settings {
+	if len(changes) == 0 {
+		return user
+	}
+	env := make(map[string]string, len(user.Env)+len(changes))
+	for k, v := range user.Env {
+		env[k] = v
+	}
+	for _, c := range changes {
+		env[c.Key] = c.Value
+	}
+	user.Env = env
+	return user
+}
+
+// MaskEnvJSON hides secret-looking env values in a settings file's content,
+// leaving everything else byte-for-byte. Content it can't parse is an error
+// rather than passed through, so secrets are never shown by accident.
+func MaskEnvJSON(data []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data, nil // a missing file
+	}
+	std, err := blankJSONC(data, true)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(std))
+	dec.UseNumber()
+	var v map[string]any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	env, ok := v["env"].(map[string]any)
+	if !ok {
+		return data, nil
+	}
+	maskEnv(env)
+	return EditJSON(data, v)
+}
+
+// MaskJSONPatch returns ops with the secret-looking env values they add,
+// replace or test masked. Compute the patch from the unmasked content first,
+// so every operation still targets the right place.
+func MaskJSONPatch(ops []JSONPatchOp) ([]JSONPatchOp, error) {
+	masked := make([]JSONPatchOp, len(ops))
+	for i, op := range ops {
+		masked[i] = op
+		if op.Value == nil {
+			continue
+		}
+		tokens, err := parsePointer(op.Path)
+		if err != nil || len(tokens) > 2 || len(tokens) > 0 && tokens[0] != "env" {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(op.Value))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		switch len(tokens) {
+		case 0:
+			root, _ := v.(map[string]any)
+			env, _ := root["env"].(map[string]any)
+			maskEnv(env)
+		case 1:
+			env, _ := v.(map[string]any)
+			maskEnv(env)
+		case 2:
+			if s, ok := v.(string); ok && LooksSecret(tokens[1], s) != "" {
+				v = MaskValue(s)
+			}
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		masked[i].Value = raw
+	}
+	return masked, nil
+}
+
+// maskEnv masks the secret-looking values of an env object in place.
+func maskEnv(env map[string]any) {
+	for k, val := range env {
+		if s, ok := val.(string); ok && LooksSecret(k, s) != "" {
+			env[k] = MaskValue(s)
+		}
+	}
+}
+
+// MaskValue hides a value behind up to eight asterisks.
+func MaskValue(v string) string {
+	return strings.Repeat("*", min(len(v), 8))
+}
+
+// MaskedEnv returns the env variables after sets to what MaskValue returns
+// while before doesn't, sorted. A patch saved from masked output would
+// write those asterisks in place of the real values. Content that doesn't
+// parse has no env.
+func MaskedEnv(before, after []byte) []string {
+	var b, a Settings
+	UnmarshalJSONC(before, &b)
+	UnmarshalJSONC(after, &a)
+	var keys []string
+	for k, v := range a.Env {
+		if v != "" && len(v) <= 8 && strings.Trim(v, "*") == "" && b.Env[k] != v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var secretKeyWords = []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "API_KEY", "APIKEY", "PRIVATE_KEY", "CREDENTIAL"}
+
+var secretPrefixes = []string{"sk-", "ghp_", "gho_", "ghs_", "github_pat_", "glpat-", "xoxb-", "xoxp-", "AKIA", "ASIA", "-----BEGIN"}
+
+// LooksSecret returns why an env variable looks like it holds a secret, or
+// "" if it doesn't. It checks the key name, well-known token prefixes and
+// the value's entropy.
+func LooksSecret(key, value string) string {
+	upper := strings.ToUpper(key)
+	for _, w := range secretKeyWords {
+		if strings.Contains(upper, w) {
+			return "key name contains " + w
+		}
+	}
+	// AUTH only as a word of its own, or ending one as in OAUTH, so that
+	// GIT_AUTHOR_NAME doesn't look secret.
+	for _, part := range strings.Split(upper, "_") {
+		if strings.HasSuffix(part, "AUTH") {
+			return "key name contains AUTH"
+		}
+	}
+	for _, p := range secretPrefixes {
+		if strings.HasPrefix(value, p) {
+			return "value starts with " + p
+		}
+	}
+	if len(value) >= 20 && !strings.ContainsAny(value, " /") && entropy(value) >= 3.5 && hasLettersAndDigits(value) {
+		return "high-entropy value"
+	}
+	return ""
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+func hasLettersAndDigits(s string) bool {
+	var letter, digit bool
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digit = true
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+			letter = true
+		}
+	}
+	return letter && digit
+}
@@ -0,0 +1,131 @@
+package hoist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLooksSecret(t *testing.T) {
+	tests := []struct {
+		key, value string
+		secret     bool
+	}{
+		{"GOFLAGS", "-mod=mod", false},
+		{"AWS_PROFILE", "dev", false},
+		{"GITHUB_TOKEN", "anything", true},
+		{"DB_PASSWORD", "hunter2", true},
+		{"OPENAI", "sk-abc", true},
+		{"GH", "ghp_1234567890", true},
+		{"BLOB", "q8Zr2LmX7vPw4KtN9sYb3HjD", true},
+		{"PATH_EXTRA", "/usr/local/share/some/long/path/here", false},
+		{"GREETING", "hello there everyone at all", false},
+		{"GIT_AUTHOR_NAME", "me", false},
+		{"AUTHOR", "me", false},
+		{"AUTH_HEADER", "x", true},
+		{"NPM_AUTH", "x", true},
+		{"GITHUB_OAUTH", "x", true},
+	}
+	for _, tt := range tests {
+		got := LooksSecret(tt.key, tt.value)
+		if (got != "") != tt.secret {
+			t.Errorf("LooksSecret(%q, %q) = %q, want secret=%v", tt.key, tt.value, got, tt.secret)
+		}
+	}
+}
+
+func TestDiffEnv(t *testing.T) {
+	project := Settings{Env: map[string]string{
+		"GOFLAGS":      "-mod=mod",
+		"AWS_PROFILE":  "prod",
+		"SAME":         "1",
+		"GITHUB_TOKEN": "ghp_x",
+	}}
+	user := Settings{Env: map[string]string{"AWS_PROFILE": "dev", "SAME": "1"}}
+
+	got := DiffEnv(project, user)
+	if len(got) != 3 {
+		t.Fatalf("got %d changes, want 3: %+v", len(got), got)
+	}
+	if got[0].Key != "AWS_PROFILE" || !got[0].Conflict || got[0].UserValue != "dev" {
+		t.Fatalf("got[0] = %+v", got[0])
+	}
+	if got[1].Key != "GITHUB_TOKEN" || got[1].Secret == "" {
+		t.Fatalf("got[1] = %+v", got[1])
+	}
+	if got[2].Key != "GOFLAGS" || got[2].Conflict || got[2].Secret != "" {
+		t.Fatalf("got[2] = %+v", got[2])
+	}
+
+	merged := MergeEnv(user, got[2:])
+	if merged.Env["GOFLAGS"] != "-mod=mod" || merged.Env["AWS_PROFILE"] != "dev" {
+		t.Fatalf("merged: %v", merged.Env)
+	}
+	if _, ok := user.Env["GOFLAGS"]; ok {
+		t.Fatal("MergeEnv modified the input settings")
+	}
+}
+
+func TestMaskEnvJSON(t *testing.T) {
+	src := "{\n  \"env\": {\n    \"API_KEY\": \"abcdefghijkl\",\n    \"MODE\": \"fast\"\n  }\n}\n"
+	want := "{\n  \"env\": {\n    \"API_KEY\": \"********\",\n    \"MODE\": \"fast\"\n  }\n}\n"
+	if got, err := MaskEnvJSON([]byte(src)); err != nil || string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s (%v)", got, want, err)
+	}
+
+	src = "{\n  // secrets\n  \"env\": {\n    \"API_KEY\": \"abcdefghijkl\", // keep this\n  },\n}\n"
+	want = "{\n  // secrets\n  \"env\": {\n    \"API_KEY\": \"********\", // keep this\n  },\n}\n"
+	if got, err := MaskEnvJSON([]byte(src)); err != nil || string(got) != want {
+		t.Fatalf("comments and trailing commas: got:\n%s\nwant:\n%s (%v)", got, want, err)
+	}
+
+	if got, err := MaskEnvJSON(nil); err != nil || len(got) != 0 {
+		t.Fatalf("missing file: got %q, %v", got, err)
+	}
+
+	if got, err := MaskEnvJSON([]byte(`{"env": {"API_KEY": "abcdefghijkl"`)); err == nil {
+		t.Fatalf("invalid input passed through: %q", got)
+	}
+}
+
+func TestMaskJSONPatch(t *testing.T) {
+	before := `{"env": {"API_KEY": "old-secret-value"}}`
+	after := `{"env": {"API_KEY": "abcdefghijkl", "MODE": "fast"}, "model": "opus"}`
+	ops, err := DiffJSONPatch([]byte(before), []byte(after))
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked, err := MaskJSONPatch(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(masked) != len(ops) {
+		t.Fatalf("got %d ops, want %d", len(masked), len(ops))
+	}
+	for i, op := range masked {
+		if op.Op != ops[i].Op || op.Path != ops[i].Path {
+			t.Errorf("op %d changed: %v, want %v", i, op, ops[i])
+		}
+		if strings.Contains(string(op.Value), "secret") || strings.Contains(string(op.Value), "abcdef") {
+			t.Errorf("%v shows a secret: %s", op, op.Value)
+		}
+	}
+
+	ops, _ = DiffJSONPatch([]byte(`{}`), []byte(after))
+	masked, _ = MaskJSONPatch(ops)
+	for _, op := range masked {
+		if op.Path == "/env" && string(op.Value) != `{"API_KEY":"********","MODE":"fast"}` {
+			t.Errorf("whole env: got %s", op.Value)
+		}
+	}
+}
+
+func TestMaskedEnv(t *testing.T) {
+	before := `{"env": {"KEPT": "****", "OLD": "x"}}`
+	after := `{"env": {"KEPT": "****", "OLD": "x", "API_KEY": "********", "MODE": "fast"}}`
+	if got := MaskedEnv([]byte(before), []byte(after)); strings.Join(got, ",") != "API_KEY" {
+		t.Errorf("got %v", got)
+	}
+	if got := MaskedEnv(nil, []byte(`{"env": {"A": "*****", "B": "*********"}}`)); strings.Join(got, ",") != "A" {
+		t.Errorf("new file: got %v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package hoist
+
+// Match is a rule that covers a tool call, along with where it came from.
+type Match struct {
+	List  List
+	Rule  string
+	Scope Scope
+	Path  string
+}
+
+// Explain returns every rule across scopes that matches call, ordered the
+// way Claude Code evaluates them: deny before ask before allow, and within a
+// list by scope precedence. The first match, if any, decides the call.
+// Rules that fail to parse are ignored.
+func Explain(call ToolCall, scopes []ScopeSettings) []Match {
+	var matches []Match
+	for _, list := range Lists {
+		for _, sc := range scopes {
+			for _, raw := range sc.Settings.Permissions.Rules(list) {
+				rule, err := ParseRule(raw)
+				if err != nil || !rule.Matches(call) {
+					continue
+				}
+				matches = append(matches, Match{List: list, Rule: raw, Scope: sc.Scope, Path: sc.Path})
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,153 @@
+package hoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Settings files carry many keys claude-hoist doesn't model. The types below
+// keep those keys in an Extra map so reading and writing a file never drops them.
+
+func (s *Settings) UnmarshalJSON(data []byte) error {
+	type plain Settings
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	extra, err := unknownKeys(data, reflect.TypeOf(p))
+	if err != nil {
+		return err
+	}
+	*s = Settings(p)
+	s.Extra = extra
+	return nil
+}
+
+func (s Settings) MarshalJSON() ([]byte, error) {
+	type plain Settings
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (p *Permissions) UnmarshalJSON(data []byte) error {
+	type plain Permissions
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownKeys(data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	*p = Permissions(v)
+	p.Extra = extra
+	return nil
+}
+
+func (p Permissions) MarshalJSON() ([]byte, error) {
+	type plain Permissions
+	return marshalWithExtra(plain(p), p.Extra)
+}
+
+func (m *HookMatcher) UnmarshalJSON(data []byte) error {
+	type plain HookMatcher
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownKeys(data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	*m = HookMatcher(v)
+	m.Extra = extra
+	return nil
+}
+
+func (m HookMatcher) MarshalJSON() ([]byte, error) {
+	type plain HookMatcher
+	return marshalWithExtra(plain(m), m.Extra)
+}
+
+func (h *HookCommand) UnmarshalJSON(data []byte) error {
+	type plain HookCommand
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	extra, err := unknownKeys(data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	*h = HookCommand(v)
+	h.Extra = extra
+	return nil
+}
+
+func (h HookCommand) MarshalJSON() ([]byte, error) {
+	type plain HookCommand
+	return marshalWithExtra(plain(h), h.Extra)
+}
+
+// unknownKeys returns the members of a JSON object that don't correspond to
+// a field of struct type t.
+func unknownKeys(data []byte, t reflect.Type) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for name := range jsonFields(t) {
+		delete(all, name)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+func jsonFields(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// marshalWithExtra encodes v and appends the extra members after its own
+// fields, in sorted key order.
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
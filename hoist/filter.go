@@ -0,0 +1,74 @@
+package hoist
+
+import "fmt"
+
+// Filter selects rules by tool, list and pattern. A zero Filter keeps every
+// rule; each non-empty field narrows the selection further.
+type Filter struct {
+	Tools      []string // tool names, e.g. Bash or mcp__github__create_issue
+	Lists      []List
+	Match      []string // globs the whole rule must match one of; * matches anything
+	Exclude    []string // globs of rules to drop
+	MCPServers []string // keep only rules for tools on these MCP servers
+}
+
+// ParseList converts a list name such as "allow" to a List.
+func ParseList(s string) (List, error) {
+	for _, l := range Lists {
+		if s == string(l) {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("unknown list %q (want allow, ask or deny)", s)
+}
+
+// Keep reports whether the filter selects rule from list.
+func (f Filter) Keep(list List, rule string) bool {
+	if len(f.Lists) > 0 && !contains(listNames(f.Lists), string(list)) {
+		return false
+	}
+	if len(f.Tools) > 0 {
+		r, err := ParseRule(rule)
+		if err != nil || !contains(f.Tools, r.Tool) {
+			return false
+		}
+	}
+	if len(f.MCPServers) > 0 {
+		server, ok := MCPServer(rule)
+		if !ok || !contains(f.MCPServers, server) {
+			return false
+		}
+	}
+	if len(f.Match) > 0 && !anyGlob(f.Match, rule) {
+		return false
+	}
+	return !anyGlob(f.Exclude, rule)
+}
+
+// Apply returns the rules from list that the filter keeps.
+func (f Filter) Apply(list List, rules []string) []string {
+	var result []string
+	for _, r := range rules {
+		if f.Keep(list, r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func anyGlob(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if globMatch(p, s, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func listNames(lists []List) []string {
+	names := make([]string, len(lists))
+	for i, l := range lists {
+		names[i] = string(l)
+	}
+	return names
+}
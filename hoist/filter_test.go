@@ -0,0 +1,46 @@
+package hoist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	rules := []string{
+		"Bash(git status)",
+		"Bash(npm run test:*)",
+		"Read(~/.ssh/**)",
+		"WebFetch(domain:github.com)",
+		"mcp__github__create_issue",
+		"mcp__linear",
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		list   List
+		want   []string
+	}{
+		{"zero filter keeps everything", Filter{}, ListAllow, rules},
+		{"by tool", Filter{Tools: []string{"Bash", "Read"}}, ListAllow, []string{"Bash(git status)", "Bash(npm run test:*)", "Read(~/.ssh/**)"}},
+		{"by list", Filter{Lists: []List{ListDeny}}, ListAllow, nil},
+		{"match", Filter{Match: []string{"*git*"}}, ListAllow, []string{"Bash(git status)", "WebFetch(domain:github.com)", "mcp__github__create_issue"}},
+		{"exclude", Filter{Tools: []string{"Bash"}, Exclude: []string{"Bash(npm *"}}, ListAllow, []string{"Bash(git status)"}},
+		{"mcp server", Filter{MCPServers: []string{"github", "linear"}}, ListAllow, []string{"mcp__github__create_issue", "mcp__linear"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Apply(tt.list, rules); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	if l, err := ParseList("ask"); err != nil || l != ListAsk {
+		t.Fatalf("ParseList(ask) = %q, %v", l, err)
+	}
+	if _, err := ParseList("allowed"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,142 @@
+package hoist
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS is the file system a Client reads and writes settings files through.
+// Names are paths as the host spells them, absolute or relative, not the
+// slash-separated names of io/fs. Missing files are reported with an error
+// matching fs.ErrNotExist.
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	// WriteFile replaces the file as a whole: readers see either the old or
+	// the new content. An existing file keeps its permissions.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// Locker is implemented by file systems that can take an advisory lock on
+// a file. Client.Apply holds it around reading and rewriting the user
+// config; on file systems without one, writes aren't coordinated.
+type Locker interface {
+	// Lock blocks until it holds the lock on name, or fails after a timeout.
+	Lock(name string) (unlock func(), err error)
+}
+
+// lockTimeout is how long Lock waits for another process to let go, and
+// lockPoll how often it checks.
+var (
+	lockTimeout = 10 * time.Second
+	lockPoll    = 50 * time.Millisecond
+)
+
+// OSFS is the host file system. Writes go through WriteFileAtomic, and
+// locks are taken on a name + ".lock" file next to the settings file.
+type OSFS struct{}
+
+func (OSFS) ReadFile(name string) ([]byte, error)  { return os.ReadFile(name) }
+func (OSFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return WriteFileAtomic(name, data, perm)
+}
+
+func (OSFS) Lock(name string) (func(), error) { return lockFile(name) }
+
+// MemFS is an in-memory FS for tests and for callers that want to plan
+// changes without touching disk. The zero value is empty and ready to use;
+// directories exist implicitly when a file below them does.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string]memFile
+}
+
+type memFile struct {
+	data []byte
+	perm fs.FileMode
+}
+
+// NewMemFS returns a MemFS holding the given files, keyed by path.
+func NewMemFS(files map[string]string) *MemFS {
+	m := &MemFS{}
+	for name, data := range files {
+		m.WriteFile(name, []byte(data), 0600)
+	}
+	return m
+}
+
+func memKey(name string) string {
+	return filepath.ToSlash(filepath.Clean(name))
+}
+
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.files[memKey(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.files == nil {
+		m.files = make(map[string]memFile)
+	}
+	key := memKey(name)
+	if f, ok := m.files[key]; ok {
+		perm = f.perm
+	}
+	m.files[key] = memFile{data: append([]byte(nil), data...), perm: perm}
+	return nil
+}
+
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := memKey(name)
+	if f, ok := m.files[key]; ok {
+		return memInfo{name: path.Base(key), size: int64(len(f.data)), mode: f.perm}, nil
+	}
+	for k := range m.files {
+		if strings.HasPrefix(k, strings.TrimSuffix(key, "/")+"/") {
+			return memInfo{name: path.Base(key), mode: fs.ModeDir | 0755}, nil
+		}
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// Files returns the paths of every file, sorted.
+func (m *MemFS) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.files))
+	for k := range m.files {
+		names = append(names, filepath.FromSlash(k))
+	}
+	sort.Strings(names)
+	return names
+}
+
+type memInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return i.size }
+func (i memInfo) Mode() fs.FileMode  { return i.mode }
+func (i memInfo) ModTime() time.Time { return time.Time{} }
+func (i memInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i memInfo) Sys() any           { return nil }
@@ -0,0 +1,202 @@
+package hoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HookMatcher is one entry in a hook event's list: the hooks to run for
+// tools matching Matcher.
+type HookMatcher struct {
+	Matcher string        `json:"matcher,omitempty"`
+	Hooks   []HookCommand `json:"hooks"`
+
+	Extra map[string]json.RawMessage `json:"-"` // keys claude-hoist doesn't model
+}
+
+// HookCommand is a single hook.
+type HookCommand struct {
+	Type    string `json:"type"`
+	Command string `json:"command,omitempty"`
+	Timeout int    `json:"timeout,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // keys claude-hoist doesn't model
+}
+
+// HookChange is a hook the project defines that the user config lacks.
+type HookChange struct {
+	Event   string
+	Matcher string
+	Hook    HookCommand
+	Warning string // set when the hook is unlikely to work outside the project
+}
+
+func (c HookChange) String() string {
+	matcher := c.Matcher
+	if matcher == "" {
+		matcher = "*"
+	}
+	return fmt.Sprintf("%s [%s] %s", c.Event, matcher, c.Hook.Command)
+}
+
+// sameHook compares hooks by what they run, ignoring keys we don't model.
+func sameHook(a, b HookCommand) bool {
+	return a.Type == b.Type && a.Command == b.Command && a.Timeout == b.Timeout
+}
+
+// DiffHooks returns the project's hooks that aren't in the user config,
+// compared per event and matcher. Identical entries are reported once.
+func DiffHooks(project, user Settings) []HookChange {
+	events := make([]string, 0, len(project.Hooks))
+	for event := range project.Hooks {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	var result []HookChange
+	for _, event := range events {
+		for _, m := range project.Hooks[event] {
+			for _, h := range m.Hooks {
+				if hasHook(user.Hooks[event], m.Matcher, h) || containsChange(result, event, m.Matcher, h) {
+					continue
+				}
+				result = append(result, HookChange{
+					Event:   event,
+					Matcher: m.Matcher,
+					Hook:    h,
+					Warning: projectRelativeWarning(h.Command),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// joinHooks returns the matchers of a and b together, per event.
+func joinHooks(a, b map[string][]HookMatcher) map[string][]HookMatcher {
+	result := make(map[string][]HookMatcher, len(a)+len(b))
+	for _, hooks := range []map[string][]HookMatcher{a, b} {
+		for event, matchers := range hooks {
+			result[event] = append(result[event], matchers...)
+		}
+	}
+	return result
+}
+
+func hasHook(matchers []HookMatcher, matcher string, h HookCommand) bool {
+	for _, m := range matchers {
+		if m.Matcher != matcher {
+			continue
+		}
+		for _, existing := range m.Hooks {
+			if sameHook(existing, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsChange(changes []HookChange, event, matcher string, h HookCommand) bool {
+	for _, c := range changes {
+		if c.Event == event && c.Matcher == matcher && sameHook(c.Hook, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeHooks adds hooks to the user config, grouping them under an existing
+// matcher entry for the same event when there is one.
+func MergeHooks(user Settings, changes []HookChange) Settings {
+	if len(changes) == 0 {
+		return user
+	}
+
+	hooks := make(map[string][]HookMatcher, len(user.Hooks))
+	for event, matchers := range user.Hooks {
+		hooks[event] = append([]HookMatcher(nil), matchers...)
+	}
+
+	for _, c := range changes {
+		if hasHook(hooks[c.Event], c.Matcher, c.Hook) {
+			continue
+		}
+		matchers := hooks[c.Event]
+		i := 0
+		for i < len(matchers) && matchers[i].Matcher != c.Matcher {
+			i++
+		}
+		if i == len(matchers) {
+			hooks[c.Event] = append(matchers, HookMatcher{Matcher: c.Matcher, Hooks: []HookCommand{c.Hook}})
+			continue
+		}
+		m := matchers[i]
+		m.Hooks = append(append([]HookCommand(nil), m.Hooks...), c.Hook)
+		matchers[i] = m
+	}
+
+	user.Hooks = hooks
+	return user
+}
+
+// RemoveHooks drops every hook drop reports true for, along with matcher
+// entries and events left without hooks, and returns how many it dropped.
+func RemoveHooks(user Settings, drop func(event string, h HookCommand) bool) (Settings, int) {
+	n := 0
+	hooks := make(map[string][]HookMatcher, len(user.Hooks))
+	for event, matchers := range user.Hooks {
+		var kept []HookMatcher
+		for _, m := range matchers {
+			var cmds []HookCommand
+			for _, h := range m.Hooks {
+				if drop(event, h) {
+					n++
+					continue
+				}
+				cmds = append(cmds, h)
+			}
+			if len(cmds) > 0 {
+				m.Hooks = cmds
+				kept = append(kept, m)
+			}
+		}
+		if len(kept) > 0 {
+			hooks[event] = kept
+		}
+	}
+	if n == 0 {
+		return user, 0
+	}
+	user.Hooks = hooks
+	if len(hooks) == 0 {
+		user.Hooks = nil
+	}
+	return user, n
+}
+
+// projectRelativeWarning explains why a hook command won't resolve from
+// other repositories, or returns "" if it looks portable. Any relative path
+// among its words, such as scripts/fmt.sh or the tools/lint.js in
+// "node tools/lint.js", is taken to be in the project; words without a slash
+// are commands looked up on $PATH.
+func projectRelativeWarning(command string) string {
+	if strings.Contains(command, "$CLAUDE_PROJECT_DIR") || strings.Contains(command, "${CLAUDE_PROJECT_DIR}") {
+		return "runs a script under $CLAUDE_PROJECT_DIR, which points at whichever project is open"
+	}
+	for _, word := range strings.Fields(command) {
+		word = strings.TrimLeft(word, "0123456789<>&") // redirections such as 2>/dev/null
+		if _, value, ok := strings.Cut(word, "="); ok {
+			word = value // --config=path or VAR=path
+		}
+		word = strings.Trim(word, `"'`)
+		if !strings.Contains(word, "/") || strings.Contains(word, "://") || strings.HasPrefix(word, "-") ||
+			strings.HasPrefix(word, "/") || strings.HasPrefix(word, "~") || strings.HasPrefix(word, "$") {
+			continue
+		}
+		return fmt.Sprintf("references %s relative to the project; it won't resolve from other repos", word)
+	}
+	return ""
+}
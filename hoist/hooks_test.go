@@ -0,0 +1,109 @@
+package hoist
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffHooks(t *testing.T) {
+	var project, user Settings
+	json.Unmarshal([]byte(`{"hooks":{
+		"PostToolUse":[{"matcher":"Edit|Write","hooks":[
+			{"type":"command","command":"gofmt -w"},
+			{"type":"command","command":"./scripts/lint.sh"}]}],
+		"PreToolUse":[
+			{"matcher":"Bash","hooks":[{"type":"command","command":"audit"}]},
+			{"matcher":"Bash","hooks":[{"type":"command","command":"audit"}]}],
+		"Stop":[{"hooks":[{"type":"command","command":"$CLAUDE_PROJECT_DIR/notify"}]}]
+	}}`), &project)
+	json.Unmarshal([]byte(`{"hooks":{
+		"PostToolUse":[{"matcher":"Edit|Write","hooks":[{"type":"command","command":"gofmt -w"}]}]
+	}}`), &user)
+
+	got := DiffHooks(project, user)
+	if len(got) != 3 {
+		t.Fatalf("got %d changes, want 3: %+v", len(got), got)
+	}
+	if got[0].Event != "PostToolUse" || got[0].Hook.Command != "./scripts/lint.sh" || got[0].Warning == "" {
+		t.Fatalf("got[0] = %+v", got[0])
+	}
+	if got[1].Event != "PreToolUse" || got[1].Warning != "" {
+		t.Fatalf("got[1] = %+v", got[1])
+	}
+	if got[2].Event != "Stop" || got[2].Warning == "" {
+		t.Fatalf("got[2] = %+v", got[2])
+	}
+
+	merged := MergeHooks(user, got)
+	post := merged.Hooks["PostToolUse"]
+	if len(post) != 1 || len(post[0].Hooks) != 2 {
+		t.Fatalf("PostToolUse should extend the existing matcher: %+v", post)
+	}
+	if len(merged.Hooks["PreToolUse"]) != 1 || len(merged.Hooks["Stop"]) != 1 {
+		t.Fatalf("merged: %+v", merged.Hooks)
+	}
+	if len(user.Hooks["PostToolUse"][0].Hooks) != 1 {
+		t.Fatal("MergeHooks modified the input settings")
+	}
+	if again := DiffHooks(project, merged); len(again) != 0 {
+		t.Fatalf("expected nothing left after merge, got %+v", again)
+	}
+}
+
+func TestProjectRelativeWarning(t *testing.T) {
+	for command, want := range map[string]bool{
+		"./scripts/lint.sh":              true,
+		"../tools/fmt":                   true,
+		"scripts/fmt.sh":                 true,
+		"node tools/lint.js":             true,
+		"eslint --config=conf/eslint.js": true,
+		"$CLAUDE_PROJECT_DIR/notify":     true,
+		"gofmt -w":                       false,
+		"/usr/local/bin/notify":          false,
+		"~/bin/notify 2>/dev/null":       false,
+		"curl -s https://example.com/x":  false,
+		"$HOME/bin/notify":               false,
+	} {
+		if got := projectRelativeWarning(command) != ""; got != want {
+			t.Errorf("projectRelativeWarning(%q): got warning %v, want %v", command, got, want)
+		}
+	}
+}
+
+func TestRemoveHooks(t *testing.T) {
+	var user Settings
+	json.Unmarshal([]byte(`{"hooks":{
+		"PostToolUse":[{"matcher":"Edit","hooks":[
+			{"type":"command","command":"gofmt -w"},
+			{"type":"command","command":"claude-hoist hook run"}]}],
+		"SessionEnd":[{"hooks":[{"type":"command","command":"claude-hoist hook run"}]}]
+	}}`), &user)
+	isHoist := func(event string, h HookCommand) bool { return h.Command == "claude-hoist hook run" }
+
+	got, n := RemoveHooks(user, isHoist)
+	if n != 2 || len(got.Hooks) != 1 || len(got.Hooks["PostToolUse"][0].Hooks) != 1 {
+		t.Fatalf("removed %d: %+v", n, got.Hooks)
+	}
+	if len(user.Hooks["PostToolUse"][0].Hooks) != 2 {
+		t.Fatal("RemoveHooks modified the input settings")
+	}
+	if got, n = RemoveHooks(got, func(string, HookCommand) bool { return true }); n != 1 || got.Hooks != nil {
+		t.Fatalf("removing the last hook should drop hooks: %d %+v", n, got.Hooks)
+	}
+}
+
+func TestHookMatcherKeepsUnknownKeys(t *testing.T) {
+	var user Settings
+	if err := json.Unmarshal([]byte(`{"hooks":{"Stop":[{"matcher":"","hooks":[{"type":"command","command":"a"}],"x-note":"keep"}]}}`), &user); err != nil {
+		t.Fatal(err)
+	}
+	merged := MergeHooks(user, []HookChange{{Event: "Stop", Hook: HookCommand{Type: "command", Command: "b"}}})
+	data, err := json.Marshal(merged.Hooks["Stop"][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"hooks":[{"type":"command","command":"a"},{"type":"command","command":"b"}],"x-note":"keep"}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,239 @@
+package hoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SyntaxError reports malformed settings at a 1-based line and column.
+type SyntaxError struct {
+	Path string
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Col, e.Msg)
+}
+
+// UnmarshalJSONC decodes JSON that may contain comments and trailing commas.
+// Syntax and type errors are reported as *SyntaxError with the position in data.
+func UnmarshalJSONC(data []byte, v any) error {
+	std, err := blankJSONC(data, true)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(std, v)
+
+	var syn *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syn):
+		line, col := position(data, int(syn.Offset)-1)
+		return &SyntaxError{Line: line, Col: col, Msg: syn.Error()}
+	case errors.As(err, &typ):
+		line, col := position(data, int(typ.Offset)-1)
+		return &SyntaxError{Line: line, Col: col, Msg: typ.Error()}
+	}
+	return err
+}
+
+// HasJSONC reports whether data uses comments or trailing commas.
+func HasJSONC(data []byte) bool {
+	std, err := blankJSONC(data, true)
+	return err == nil && !bytes.Equal(std, data)
+}
+
+// StripJSONC removes comments and trailing commas from data, dropping lines
+// that held nothing but a comment. Everything else is kept byte-for-byte.
+func StripJSONC(data []byte) ([]byte, error) {
+	return stripJSONC(data, true)
+}
+
+// FormatJSONC normalizes a settings file: it checks the content parses,
+// removes trailing commas and re-indents it with the file's own indentation
+// unit. Comments are removed unless keepComments is set, in which case the
+// layout is left alone apart from the trailing commas.
+func FormatJSONC(data []byte, keepComments bool) ([]byte, error) {
+	var v any
+	if err := UnmarshalJSONC(data, &v); err != nil {
+		return nil, err
+	}
+	out, err := stripJSONC(data, !keepComments)
+	if err != nil {
+		return nil, err
+	}
+	if HasJSONC(out) {
+		return out, nil // comments kept; can't re-indent around them
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(out), "", detectIndentUnit(out)); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func stripJSONC(data []byte, comments bool) ([]byte, error) {
+	std, err := blankJSONC(data, comments)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(std, data) {
+		return data, nil
+	}
+
+	var out []byte
+	origLines := bytes.SplitAfter(data, []byte("\n"))
+	for i, line := range bytes.SplitAfter(std, []byte("\n")) {
+		orig := origLines[i]
+		if bytes.Equal(line, orig) {
+			out = append(out, line...)
+			continue
+		}
+		if len(bytes.TrimSpace(line)) == 0 && len(bytes.TrimSpace(orig)) > 0 {
+			continue // the line only held a comment or a dangling comma
+		}
+		// Drop trailing commas outright rather than leave a space behind.
+		var kept []byte
+		for j := range line {
+			if !(orig[j] == ',' && line[j] == ' ') {
+				kept = append(kept, line[j])
+			}
+		}
+		nl := bytes.HasSuffix(line, []byte("\n"))
+		out = append(out, bytes.TrimRight(kept, " \t\r\n")...)
+		if nl {
+			out = append(out, '\n')
+		}
+	}
+	return out, nil
+}
+
+// blankJSONC replaces trailing commas, and comments when comments is set,
+// with spaces. Newlines are kept so byte offsets in the result match the input.
+func blankJSONC(data []byte, comments bool) ([]byte, error) {
+	out := append([]byte(nil), data...)
+	lastComma := -1 // offset of a comma not yet followed by a value
+
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		switch {
+		case c == '"':
+			lastComma = -1
+			i++
+			for i < len(out) && out[i] != '"' {
+				if out[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(out) {
+				line, col := position(data, len(data)-1)
+				return nil, &SyntaxError{Line: line, Col: col, Msg: "unterminated string"}
+			}
+
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for i < len(out) && out[i] != '\n' {
+				if comments {
+					out[i] = ' '
+				}
+				i++
+			}
+
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			start := i
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				line, col := position(data, start)
+				return nil, &SyntaxError{Line: line, Col: col, Msg: "unterminated comment"}
+			}
+			end += i + 4
+			for ; i < end; i++ {
+				if comments && out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+
+		case c == ',':
+			lastComma = i
+
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+
+		case !isSpace(c):
+			lastComma = -1
+		}
+	}
+	return out, nil
+}
+
+// position converts a byte offset in data to a 1-based line and column.
+func position(data []byte, offset int) (line, col int) {
+	offset = max(0, min(offset, len(data)))
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = offset - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// RuleLine returns the 1-based line on which a permissions list in data
+// holds rule, or 0 if none does. Rules are compared after parsing, so
+// surrounding whitespace doesn't matter; failing an exact rule, the first
+// line containing the text is used.
+func RuleLine(data []byte, rule string) int {
+	want := rule
+	if r, err := ParseRule(rule); err == nil {
+		want = r.String()
+	}
+
+	if root, err := parseNode(data); err == nil && root.kind == '{' {
+		for i, key := range root.keys {
+			perms := root.elems[i]
+			if key != "permissions" || perms.kind != '{' {
+				continue
+			}
+			for j, list := range perms.keys {
+				if !isRuleList(list) || perms.elems[j].kind != '[' {
+					continue
+				}
+				for _, el := range perms.elems[j].elems {
+					s, ok := el.value.(string)
+					if !ok {
+						continue
+					}
+					if r, err := ParseRule(s); s == want || err == nil && r.String() == want {
+						line, _ := position(data, el.start)
+						return line
+					}
+				}
+			}
+		}
+	}
+
+	if i := bytes.Index(data, []byte(rule)); i >= 0 {
+		line, _ := position(data, i)
+		return line
+	}
+	return 0
+}
+
+func isRuleList(key string) bool {
+	for _, l := range Lists {
+		if key == string(l) {
+			return true
+		}
+	}
+	return false
+}
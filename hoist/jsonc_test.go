@@ -0,0 +1,131 @@
+package hoist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnmarshalJSONC(t *testing.T) {
+	src := `{
+  // rules I approved by hand
+  "permissions": {
+    "allow": [
+      "Bash(ls:*)", /* listing */
+      "Read",
+    ],
+  },
+}`
+	var s Settings
+	if err := UnmarshalJSONC([]byte(src), &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Permissions.Allow) != 2 {
+		t.Fatalf("allow: got %v", s.Permissions.Allow)
+	}
+}
+
+func TestUnmarshalJSONCErrorPosition(t *testing.T) {
+	tests := []struct {
+		src       string
+		line, col int
+	}{
+		{"{\n  \"a\": 1\n  \"b\": 2\n}", 3, 3},
+		{"{\n  \"a\": tru\n}", 2, 11},
+		{"{\n  /* never closed\n}", 2, 3},
+		{"{\n  \"a\": \"open\n}", 3, 1},
+	}
+	for _, tt := range tests {
+		var v any
+		err := UnmarshalJSONC([]byte(tt.src), &v)
+		var syn *SyntaxError
+		if !errors.As(err, &syn) {
+			t.Errorf("%q: expected *SyntaxError, got %v", tt.src, err)
+			continue
+		}
+		if syn.Line != tt.line || syn.Col != tt.col {
+			t.Errorf("%q: got %d:%d, want %d:%d (%v)", tt.src, syn.Line, syn.Col, tt.line, tt.col, syn)
+		}
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	src := "{\n  // comment line\n  \"a\": [1, 2,], // trailing\n  \"b\": \"http://x\" /* c */\n}\n"
+	want := "{\n  \"a\": [1, 2],\n  \"b\": \"http://x\"\n}\n"
+	got, err := StripJSONC([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestFormatJSONC(t *testing.T) {
+	src := "{\"permissions\": {\"allow\": [\"Read\",],}, // x\n\"model\": \"opus\"}"
+
+	got, err := FormatJSONC([]byte(src), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n  \"permissions\": {\n    \"allow\": [\n      \"Read\"\n    ]\n  },\n  \"model\": \"opus\"\n}\n"
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	kept, err := FormatJSONC([]byte(src), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !HasJSONC(kept) {
+		t.Fatalf("comment was dropped: %s", kept)
+	}
+}
+
+func TestWriteSettingsStripsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.local.json")
+	os.WriteFile(path, []byte("{\n  // mine\n  \"permissions\": {\n    \"allow\": [\n      \"Read\",\n    ]\n  }\n}\n"), 0600)
+
+	s, err := ReadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSettings(path, Merge(s, []string{"WebSearch"}, nil)); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := os.ReadFile(path)
+	want := "{\n  \"permissions\": {\n    \"allow\": [\n      \"Read\",\n      \"WebSearch\"\n    ]\n  }\n}\n"
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRuleLine(t *testing.T) {
+	data := []byte(`{
+  // "Read" is mentioned here first
+  "permissions": {
+    "allow": [
+      "Read",
+      " Bash(ls:*)"
+    ],
+    "deny": ["WebFetch"]
+  },
+  "model": "opus"
+}`)
+	tests := []struct {
+		rule string
+		want int
+	}{
+		{"Read", 5},
+		{"Bash(ls:*)", 6},
+		{"WebFetch", 8},
+		{"opus", 10},
+		{"Write", 0},
+	}
+	for _, tt := range tests {
+		if got := RuleLine(data, tt.rule); got != tt.want {
+			t.Errorf("RuleLine(%q) = %d, want %d", tt.rule, got, tt.want)
+		}
+	}
+}
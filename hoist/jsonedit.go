@@ -0,0 +1,530 @@
+package hoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EditJSON returns src changed to hold the value v with as small an edit as
+// possible. Members and elements that don't change keep their bytes, key
+// order, indentation and the trailing newline; new array elements and object
+// members are inserted using the layout of their neighbours.
+func EditJSON(src []byte, v any) ([]byte, error) {
+	root, err := parseNode(src)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+
+	e := editor{src: src, unit: detectIndentUnit(src)}
+	e.value(root, want)
+
+	// Apply from the end so earlier offsets stay valid. At equal offsets a
+	// deletion goes before an insertion, so it can't swallow the new text.
+	sort.SliceStable(e.edits, func(i, j int) bool {
+		a, b := e.edits[i], e.edits[j]
+		if a.start != b.start {
+			return a.start > b.start
+		}
+		return a.end > b.end
+	})
+	out := append([]byte(nil), src...)
+	for _, ed := range e.edits {
+		out = append(out[:ed.start], append([]byte(ed.text), out[ed.end:]...)...)
+	}
+	return out, nil
+}
+
+// toGeneric converts v to the map/slice/json.Number form parseNode produces.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// node is a parsed JSON value with its position in the source.
+type node struct {
+	kind       byte // '{', '[' or 'v' for scalars
+	start, end int  // byte span of the value
+	keys       []string
+	keyStarts  []int   // offset of each object key
+	elems      []*node // object member values or array elements
+	value      any     // decoded value, for comparison
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+type editor struct {
+	src   []byte
+	unit  string // one level of indentation
+	edits []edit
+}
+
+func (e *editor) replace(start, end int, text string) {
+	e.edits = append(e.edits, edit{start, end, text})
+}
+
+// value edits n so it holds want.
+func (e *editor) value(n *node, want any) {
+	if reflect.DeepEqual(n.value, want) {
+		return
+	}
+	switch w := want.(type) {
+	case map[string]any:
+		if n.kind == '{' {
+			e.object(n, w)
+			return
+		}
+	case []any:
+		if n.kind == '[' {
+			e.array(n, w)
+			return
+		}
+	}
+	e.replace(n.start, n.end, e.encode(want, e.lineIndent(n.start), !e.inline(n)))
+}
+
+func (e *editor) object(n *node, want map[string]any) {
+	var items []item
+	have := make(map[string]bool, len(n.keys))
+	for i, k := range n.keys {
+		have[k] = true
+		if v, ok := want[k]; ok {
+			e.value(n.elems[i], v)
+			items = append(items, item{index: i})
+		}
+	}
+
+	// New members go at the end, in sorted order.
+	var added []string
+	for k := range want {
+		if !have[k] {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+
+	l := e.layout(n)
+	for _, k := range added {
+		name, _ := json.Marshal(k)
+		items = append(items, item{index: -1, text: string(name) + ": " + e.encode(want[k], l.indent, l.multiline)})
+	}
+	e.rewrite(n, l, items)
+}
+
+// array aligns the existing elements with want by longest common
+// subsequence, so unchanged elements keep their bytes and position.
+func (e *editor) array(n *node, want []any) {
+	old := n.elems
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(want)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(want) - 1; j >= 0; j-- {
+			if reflect.DeepEqual(old[i].value, want[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	l := e.layout(n)
+	var items []item
+	i, j := 0, 0
+	for i < len(old) || j < len(want) {
+		switch {
+		case i < len(old) && j < len(want) && reflect.DeepEqual(old[i].value, want[j]):
+			items = append(items, item{index: i})
+			i++
+			j++
+		case j < len(want) && (i == len(old) || lcs[i][j+1] >= lcs[i+1][j]):
+			items = append(items, item{index: -1, text: e.encode(want[j], l.indent, l.multiline)})
+			j++
+		default:
+			i++
+		}
+	}
+	e.rewrite(n, l, items)
+}
+
+// item is one element of a container after editing: an original element
+// (index >= 0) or new text.
+type item struct {
+	index int
+	text  string
+}
+
+// rewrite turns n's elements into items. Original elements missing from
+// items are removed along with a separating comma; new ones are inserted next
+// to their nearest surviving neighbour.
+func (e *editor) rewrite(n *node, l containerLayout, items []item) {
+	kept := make(map[int]bool)
+	first := -1
+	for _, it := range items {
+		if it.index >= 0 {
+			kept[it.index] = true
+			if first < 0 {
+				first = it.index
+			}
+		}
+	}
+
+	if first < 0 {
+		// Nothing survives: lay out the whole body afresh.
+		if len(items) == 0 {
+			e.replace(n.start+1, n.end-1, "")
+			return
+		}
+		texts := make([]string, len(items))
+		for i, it := range items {
+			texts[i] = it.text
+		}
+		lead, closing := "", ""
+		if l.multiline {
+			lead, closing = "\n"+l.indent, l.closing
+		}
+		e.replace(n.start+1, n.end-1, lead+strings.Join(texts, ","+l.sep)+closing)
+		return
+	}
+
+	for i := range n.elems {
+		if kept[i] {
+			continue
+		}
+		if i < first {
+			// Before the first survivor: take the following comma.
+			e.replace(e.memberStart(n, i), e.memberStart(n, i+1), "")
+		} else {
+			// After a survivor: take the preceding comma.
+			e.replace(n.elems[i-1].end, n.elems[i].end, "")
+		}
+	}
+
+	// New items before the first survivor are anchored at its start; later
+	// ones follow the survivor before them.
+	var pending []string
+	anchor := -1
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if anchor < 0 {
+			at := e.memberStart(n, first)
+			e.replace(at, at, strings.Join(pending, ","+l.sep)+","+l.sep)
+		} else if end, comma, ok := e.trailingComment(n, anchor); ok {
+			// Keep the survivor's comment on its line: insert after it,
+			// with the comma that ended up after the new items.
+			if comma {
+				e.replace(end, end, l.sep+strings.Join(pending, ","+l.sep)+",")
+			} else {
+				at := n.elems[anchor].end
+				e.replace(at, at, ",")
+				e.replace(end, end, l.sep+strings.Join(pending, ","+l.sep))
+			}
+		} else {
+			at := n.elems[anchor].end
+			e.replace(at, at, ","+l.sep+strings.Join(pending, ","+l.sep))
+		}
+		pending = nil
+	}
+	for _, it := range items {
+		if it.index < 0 {
+			pending = append(pending, it.text)
+			continue
+		}
+		flush()
+		anchor = it.index
+	}
+	flush()
+}
+
+// trailingComment returns where the comment on the same line after element i
+// ends, and whether a comma comes between them. Only the last element may
+// have a comment without a comma first; for any other, a comment before the
+// comma is left alone.
+func (e *editor) trailingComment(n *node, i int) (end int, comma, ok bool) {
+	p := n.elems[i].end
+	skip := func() {
+		for p < len(e.src) && (e.src[p] == ' ' || e.src[p] == '\t') {
+			p++
+		}
+	}
+	skip()
+	if p < len(e.src) && e.src[p] == ',' {
+		comma = true
+		p++
+		skip()
+	} else if i < len(n.elems)-1 {
+		return 0, false, false
+	}
+	rest := e.src[p:]
+	line := rest
+	if nl := bytes.IndexByte(rest, '\n'); nl >= 0 {
+		line = rest[:nl]
+	}
+	switch {
+	case bytes.HasPrefix(line, []byte("//")):
+		return p + len(line), comma, true
+	case bytes.HasPrefix(line, []byte("/*")):
+		if j := bytes.Index(line[2:], []byte("*/")); j >= 0 {
+			return p + 2 + j + 2, comma, true
+		}
+	}
+	return 0, false, false
+}
+
+// containerLayout describes how the elements of a container are laid out.
+type containerLayout struct {
+	multiline bool
+	sep       string // whitespace between a comma and the next element
+	indent    string // indentation of an element line
+	closing   string // whitespace before the closing bracket
+}
+
+func (e *editor) inline(n *node) bool {
+	return !bytes.Contains(e.src[n.start:n.end], []byte("\n"))
+}
+
+func (e *editor) layout(n *node) containerLayout {
+	base := e.lineIndent(n.start)
+	l := containerLayout{
+		multiline: !e.inline(n) || len(n.elems) == 0 && bytes.Contains(e.src, []byte("\n")),
+		indent:    base + e.unit,
+	}
+	if len(n.elems) > 0 {
+		ws := string(e.src[n.start+1 : e.memberStart(n, 0)])
+		if i := strings.LastIndexByte(ws, '\n'); i >= 0 {
+			l.indent = ws[i+1:]
+		}
+	}
+	l.sep = " "
+	l.closing = ""
+	if l.multiline {
+		l.sep = "\n" + l.indent
+		l.closing = "\n" + base
+	}
+	if len(n.elems) > 1 {
+		prevEnd := n.elems[0].end
+		next := e.memberStart(n, 1)
+		if comma := bytes.IndexByte(e.src[prevEnd:next], ','); comma >= 0 {
+			sep := string(e.src[prevEnd+comma+1 : next])
+			if i := strings.LastIndexByte(sep, '\n'); i >= 0 {
+				sep = sep[i:] // not a comment on the first element's line
+			}
+			if strings.TrimLeft(sep, " \t\r\n") == "" {
+				l.sep = sep
+			}
+		}
+	}
+	if len(n.elems) > 0 {
+		l.closing = string(e.src[n.elems[len(n.elems)-1].end : n.end-1])
+	}
+	return l
+}
+
+// memberStart returns where element i starts, including its key for objects.
+func (e *editor) memberStart(n *node, i int) int {
+	if n.kind != '{' {
+		return n.elems[i].start
+	}
+	return n.keyStarts[i]
+}
+
+// encode marshals v for insertion at the given indentation.
+func (e *editor) encode(v any, indent string, multiline bool) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if multiline {
+		enc.SetIndent(indent, e.unit)
+	}
+	enc.Encode(v)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// lineIndent returns the leading whitespace of the line containing offset.
+func (e *editor) lineIndent(offset int) string {
+	start := bytes.LastIndexByte(e.src[:offset], '\n') + 1
+	end := start
+	for end < len(e.src) && (e.src[end] == ' ' || e.src[end] == '\t') {
+		end++
+	}
+	return string(e.src[start:end])
+}
+
+// detectIndentUnit returns the indentation of the first indented line,
+// defaulting to two spaces.
+func detectIndentUnit(src []byte) string {
+	for _, line := range bytes.Split(src, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && len(trimmed) < len(line) {
+			return string(line[:len(line)-len(trimmed)])
+		}
+	}
+	return "  "
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// parseNode parses src into a node tree that records byte offsets. Comments
+// and trailing commas are accepted.
+func parseNode(src []byte) (*node, error) {
+	p := &nodeParser{src: src}
+	p.skip()
+	n, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	p.skip()
+	if p.pos != len(src) {
+		return nil, fmt.Errorf("unexpected data after JSON value at offset %d", p.pos)
+	}
+	return n, nil
+}
+
+type nodeParser struct {
+	src []byte
+	pos int
+}
+
+// skip moves past whitespace and comments.
+func (p *nodeParser) skip() {
+	for p.pos < len(p.src) {
+		switch {
+		case isSpace(p.src[p.pos]):
+			p.pos++
+		case bytes.HasPrefix(p.src[p.pos:], []byte("//")):
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case bytes.HasPrefix(p.src[p.pos:], []byte("/*")):
+			end := bytes.Index(p.src[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+func (p *nodeParser) parse() (*node, error) {
+	if p.pos >= len(p.src) {
+		return nil, fmt.Errorf("unexpected end of JSON")
+	}
+	start := p.pos
+	switch p.src[p.pos] {
+	case '{':
+		n := &node{kind: '{', start: start}
+		p.pos++
+		p.skip()
+		obj := map[string]any{}
+		for p.pos < len(p.src) && p.src[p.pos] != '}' {
+			if len(n.keys) > 0 {
+				if p.src[p.pos] != ',' {
+					return nil, fmt.Errorf("expected ',' at offset %d", p.pos)
+				}
+				p.pos++
+				p.skip()
+				if p.pos < len(p.src) && p.src[p.pos] == '}' {
+					break // trailing comma
+				}
+			}
+			key, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.value.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected object key at offset %d", key.start)
+			}
+			p.skip()
+			if p.pos >= len(p.src) || p.src[p.pos] != ':' {
+				return nil, fmt.Errorf("expected ':' at offset %d", p.pos)
+			}
+			p.pos++
+			p.skip()
+			val, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, name)
+			n.keyStarts = append(n.keyStarts, key.start)
+			n.elems = append(n.elems, val)
+			obj[name] = val.value
+			p.skip()
+		}
+		if p.pos >= len(p.src) {
+			return nil, fmt.Errorf("unexpected end of JSON in object")
+		}
+		p.pos++
+		n.end, n.value = p.pos, obj
+		return n, nil
+
+	case '[':
+		n := &node{kind: '[', start: start}
+		p.pos++
+		p.skip()
+		arr := []any{}
+		for p.pos < len(p.src) && p.src[p.pos] != ']' {
+			if len(n.elems) > 0 {
+				if p.src[p.pos] != ',' {
+					return nil, fmt.Errorf("expected ',' at offset %d", p.pos)
+				}
+				p.pos++
+				p.skip()
+				if p.pos < len(p.src) && p.src[p.pos] == ']' {
+					break // trailing comma
+				}
+			}
+			val, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			n.elems = append(n.elems, val)
+			arr = append(arr, val.value)
+			p.skip()
+		}
+		if p.pos >= len(p.src) {
+			return nil, fmt.Errorf("unexpected end of JSON in array")
+		}
+		p.pos++
+		n.end, n.value = p.pos, arr
+		return n, nil
+	}
+
+	// Scalar: let encoding/json find its extent and decode it.
+	dec := json.NewDecoder(bytes.NewReader(p.src[p.pos:]))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("offset %d: %w", p.pos, err)
+	}
+	p.pos += int(dec.InputOffset())
+	return &node{kind: 'v', start: start, end: p.pos, value: v}, nil
+}
@@ -0,0 +1,106 @@
+package hoist
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEditJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string // the value to write, as JSON
+		out  string
+	}{
+		{
+			name: "unchanged",
+			src:  "{\n    \"b\": 1,\n    \"a\": [ \"x\" ]\n}",
+			want: `{"a":["x"],"b":1}`,
+			out:  "{\n    \"b\": 1,\n    \"a\": [ \"x\" ]\n}",
+		},
+		{
+			name: "insert into sorted array keeps indentation",
+			src:  "{\n\t\"allow\": [\n\t\t\"a\",\n\t\t\"c\"\n\t]\n}\n",
+			want: `{"allow":["a","b","c","d"]}`,
+			out:  "{\n\t\"allow\": [\n\t\t\"a\",\n\t\t\"b\",\n\t\t\"c\",\n\t\t\"d\"\n\t]\n}\n",
+		},
+		{
+			name: "insert at front",
+			src:  "[\n  \"b\"\n]",
+			want: `["a","b"]`,
+			out:  "[\n  \"a\",\n  \"b\"\n]",
+		},
+		{
+			name: "inline array stays inline",
+			src:  `{"allow": ["a", "c"]}`,
+			want: `{"allow":["a","b","c"]}`,
+			out:  `{"allow": ["a", "b", "c"]}`,
+		},
+		{
+			name: "new member appended with detected indent",
+			src:  "{\n   \"model\": \"opus\"\n}",
+			want: `{"model":"opus","env":{"A":"1"}}`,
+			out:  "{\n   \"model\": \"opus\",\n   \"env\": {\n      \"A\": \"1\"\n   }\n}",
+		},
+		{
+			name: "fill empty array",
+			src:  "{\n  \"permissions\": {\n    \"allow\": []\n  }\n}",
+			want: `{"permissions":{"allow":["a"]}}`,
+			out:  "{\n  \"permissions\": {\n    \"allow\": [\n      \"a\"\n    ]\n  }\n}",
+		},
+		{
+			name: "remove members",
+			src:  "{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}",
+			want: `{"b":2}`,
+			out:  "{\n  \"b\": 2\n}",
+		},
+		{
+			name: "replace scalar",
+			src:  "{ \"defaultMode\": \"plan\", \"x\": true }",
+			want: `{"defaultMode":"acceptEdits","x":true}`,
+			out:  "{ \"defaultMode\": \"acceptEdits\", \"x\": true }",
+		},
+		{
+			name: "comments and trailing commas survive",
+			src:  "{\n  // approved tools\n  \"allow\": [\n    \"a\", /* old */\n  ],\n}\n",
+			want: `{"allow":["a","b"]}`,
+			out:  "{\n  // approved tools\n  \"allow\": [\n    \"a\", /* old */\n    \"b\",\n  ],\n}\n",
+		},
+		{
+			name: "comments stay on their element's line",
+			src:  "[\n  \"a\", // first\n  \"c\" // last\n]",
+			want: `["a","b","c","d"]`,
+			out:  "[\n  \"a\", // first\n  \"b\",\n  \"c\", // last\n  \"d\"\n]",
+		},
+		{
+			name: "no html escaping",
+			src:  "[]",
+			want: `["Bash(a > b)"]`,
+			out:  `["Bash(a > b)"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			if err := json.Unmarshal([]byte(tt.want), &v); err != nil {
+				t.Fatal(err)
+			}
+			got, err := EditJSON([]byte(tt.src), v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.out {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, tt.out)
+			}
+		})
+	}
+}
+
+func TestEditJSONInvalid(t *testing.T) {
+	for _, src := range []string{"", "{", `{"a" 1}`, "[1 2]", "[1,,]", "{} x"} {
+		if _, err := EditJSON([]byte(src), map[string]any{}); err == nil {
+			t.Errorf("EditJSON(%q): expected error", src)
+		}
+	}
+}
@@ -0,0 +1,467 @@
+package hoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPatchOp is one operation of an RFC 6902 JSON Patch.
+type JSONPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func (o JSONPatchOp) String() string {
+	if o.From != "" {
+		return fmt.Sprintf("%s %s %s", o.Op, o.From, o.Path)
+	}
+	return fmt.Sprintf("%s %s", o.Op, o.Path)
+}
+
+// ParseJSONPatch parses a JSON Patch document.
+func ParseJSONPatch(data []byte) ([]JSONPatchOp, error) {
+	var ops []JSONPatchOp
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("parsing JSON Patch: %w", err)
+	}
+	return ops, nil
+}
+
+// DiffJSONPatch returns a JSON Patch that turns the JSON document before into
+// after. Each change is preceded by a test of the value it replaces, and
+// ApplyJSONPatch refuses to add a member that has been set since, so the
+// patch refuses to apply to a file that has changed since. Arrays are
+// compared element by element, so adding a rule is a single add.
+func DiffJSONPatch(before, after []byte) ([]JSONPatchOp, error) {
+	a, err := decodeGeneric(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeGeneric(after)
+	if err != nil {
+		return nil, err
+	}
+	var ops []JSONPatchOp
+	err = diffValue(&ops, "", a, b)
+	return ops, err
+}
+
+// decodeGeneric decodes JSONC into maps, slices and json.Numbers. Empty
+// input is nil, as for a missing file.
+func decodeGeneric(data []byte) (any, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	std, err := blankJSONC(data, true)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(std))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diffValue(ops *[]JSONPatchOp, path string, a, b any) error {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	emit := func(op, path string, v any) error {
+		o := JSONPatchOp{Op: op, Path: path}
+		if op != "remove" {
+			raw, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			o.Value = raw
+		}
+		*ops = append(*ops, o)
+		return nil
+	}
+
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(a)+len(b))
+		for k := range a {
+			keys = append(keys, k)
+		}
+		for k := range b {
+			if _, ok := a[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := path + "/" + escapePointer(k)
+			av, inA := a[k]
+			bv, inB := b[k]
+			var err error
+			switch {
+			case !inB:
+				if err = emit("test", child, av); err == nil {
+					err = emit("remove", child, nil)
+				}
+			case !inA:
+				err = emit("add", child, bv)
+			default:
+				err = diffValue(ops, child, av, bv)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case []any:
+		b, ok := b.([]any)
+		if !ok {
+			break
+		}
+		ta, tb := make([]string, len(a)), make([]string, len(b))
+		for i, v := range a {
+			raw, _ := json.Marshal(v)
+			ta[i] = string(raw)
+		}
+		for i, v := range b {
+			raw, _ := json.Marshal(v)
+			tb[i] = string(raw)
+		}
+		// Indices shift as elements come and go, so the whole array is
+		// tested once; removals then run from the end and additions from
+		// the start.
+		if err := emit("test", path, a); err != nil {
+			return err
+		}
+		delA, insB := changedLines(ta, tb)
+		for i := len(a) - 1; i >= 0; i-- {
+			if delA[i] {
+				emit("remove", path+"/"+strconv.Itoa(i), nil)
+			}
+		}
+		for i, v := range b {
+			if insB[i] {
+				emit("add", path+"/"+strconv.Itoa(i), v)
+			}
+		}
+		return nil
+	}
+
+	if a == nil && path == "" {
+		return emit("add", "", b)
+	}
+	if err := emit("test", path, a); err != nil {
+		return err
+	}
+	return emit("replace", path, b)
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, fmt.Errorf("pointer %q doesn't start with /", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// ApplyJSONPatch applies a JSON Patch to a settings file. The operations run
+// in order and stop at the first that fails, in which case the result is a
+// *RejectError and nothing is changed. An add that would replace an object
+// member with a different value fails rather than overwriting it. The file
+// is edited in place so untouched lines keep their formatting; comments are
+// removed unless keepComments is set.
+func ApplyJSONPatch(data []byte, ops []JSONPatchOp, keepComments bool) ([]byte, error) {
+	doc, err := decodeGeneric(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		if doc, err = applyOp(doc, op); err != nil {
+			return nil, &RejectError{Total: len(ops), Rejects: []Reject{{
+				Where: fmt.Sprintf("operation %d (%s)", i+1, op),
+				Msg:   err.Error(),
+			}}}
+		}
+	}
+
+	base := data
+	if !keepComments {
+		if stripped, err := StripJSONC(data); err == nil {
+			base = stripped
+		}
+	}
+	if len(bytes.TrimSpace(base)) > 0 {
+		if out, err := EditJSON(base, doc); err == nil {
+			return out, nil
+		}
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func applyOp(doc any, op JSONPatchOp) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	value := func() (any, error) {
+		if len(op.Value) == 0 {
+			return nil, errors.New(`missing "value"`)
+		}
+		return decodeGeneric(op.Value)
+	}
+
+	switch op.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		// Unlike RFC 6902, an add doesn't replace a different member that
+		// appeared since the patch was made: diff emits no test for members
+		// it adds, so this is what keeps the patch from overwriting them.
+		if len(path) > 0 {
+			parent, _ := pointerGet(doc, path[:len(path)-1])
+			if obj, ok := parent.(map[string]any); ok {
+				if have, exists := obj[path[len(path)-1]]; exists && !jsonEqual(have, v) {
+					h, _ := json.Marshal(have)
+					return nil, fmt.Errorf("already set to %s", h)
+				}
+			}
+		}
+		return pointerAdd(doc, path, v)
+	case "remove":
+		return pointerRemove(doc, path)
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = pointerRemove(doc, path); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, v)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		v, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if op.Path != op.From && strings.HasPrefix(op.Path+"/", op.From+"/") {
+				return nil, errors.New("can't move a value into itself")
+			}
+			if doc, err = pointerRemove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			raw, _ := json.Marshal(v)
+			v, _ = decodeGeneric(raw)
+		}
+		return pointerAdd(doc, path, v)
+	case "test":
+		want, err := value()
+		if err != nil {
+			return nil, err
+		}
+		have, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(have, want) {
+			h, _ := json.Marshal(have)
+			w, _ := json.Marshal(want)
+			return nil, fmt.Errorf("expected %s, found %s", w, h)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unknown op %q", op.Op)
+}
+
+// jsonEqual compares decoded JSON values, treating numbers by value.
+func jsonEqual(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fa, errA := a.Float64()
+		fb, errB := b.Float64()
+		return errA == nil && errB == nil && fa == fb
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	for i, tok := range path {
+		switch c := doc.(type) {
+		case map[string]any:
+			v, ok := c[tok]
+			if !ok {
+				return nil, fmt.Errorf("%s doesn't exist", pointerString(path[:i+1]))
+			}
+			doc = v
+		case []any:
+			idx, err := arrayIndex(tok, len(c)-1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", pointerString(path[:i+1]), err)
+			}
+			doc = c[idx]
+		default:
+			return nil, fmt.Errorf("%s isn't an object or array", pointerString(path[:i]))
+		}
+	}
+	return doc, nil
+}
+
+// pointerUpdate replaces the container holding the last token of path with
+// what f returns for it.
+func pointerUpdate(doc any, path []string, f func(container any, tok string) (any, error)) (any, error) {
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := f(parent, path[len(path)-1])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pointerString(path), err)
+	}
+	if len(path) == 1 {
+		return updated, nil
+	}
+	// Slices may have been reallocated, so store the container back.
+	grand, _ := pointerGet(doc, path[:len(path)-2])
+	switch g := grand.(type) {
+	case map[string]any:
+		g[path[len(path)-2]] = updated
+	case []any:
+		idx, _ := arrayIndex(path[len(path)-2], len(g)-1)
+		g[idx] = updated
+	}
+	return doc, nil
+}
+
+func pointerAdd(doc any, path []string, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	return pointerUpdate(doc, path, func(c any, tok string) (any, error) {
+		switch c := c.(type) {
+		case map[string]any:
+			c[tok] = v
+			return c, nil
+		case []any:
+			idx := len(c)
+			if tok != "-" {
+				var err error
+				if idx, err = arrayIndex(tok, len(c)); err != nil {
+					return nil, err
+				}
+			}
+			c = append(c, nil)
+			copy(c[idx+1:], c[idx:])
+			c[idx] = v
+			return c, nil
+		}
+		return nil, errors.New("parent isn't an object or array")
+	})
+}
+
+func pointerRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	return pointerUpdate(doc, path, func(c any, tok string) (any, error) {
+		switch c := c.(type) {
+		case map[string]any:
+			if _, ok := c[tok]; !ok {
+				return nil, errors.New("doesn't exist")
+			}
+			delete(c, tok)
+			return c, nil
+		case []any:
+			idx, err := arrayIndex(tok, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:idx:idx], c[idx+1:]...), nil
+		}
+		return nil, errors.New("parent isn't an object or array")
+	})
+}
+
+// arrayIndex parses an array index token that must be at most last.
+func arrayIndex(tok string, last int) (int, error) {
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || (tok != "0" && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	if idx > last {
+		return 0, fmt.Errorf("index %d is out of range", idx)
+	}
+	return idx, nil
+}
+
+func pointerString(path []string) string {
+	if len(path) == 0 {
+		return "(root)"
+	}
+	var b strings.Builder
+	for _, t := range path {
+		b.WriteString("/" + escapePointer(t))
+	}
+	return b.String()
+}
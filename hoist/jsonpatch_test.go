@@ -0,0 +1,160 @@
+package hoist
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDiffJSONPatchRoundTrip(t *testing.T) {
+	before := `{
+  // kept
+  "permissions": {
+    "allow": ["Bash(ls:*)", "Read", "WebFetch"],
+    "deny": ["Bash(rm:*)"]
+  },
+  "model": "sonnet",
+  "a/b": 1
+}
+`
+	after := `{
+  "permissions": {
+    "allow": ["Bash(go test:*)", "Bash(ls:*)", "Read"],
+    "deny": ["Bash(rm:*)"],
+    "defaultMode": "plan"
+  },
+  "model": "opus"
+}
+`
+	ops, err := DiffJSONPatch([]byte(before), []byte(after))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []string
+	for _, op := range ops {
+		kinds = append(kinds, op.String())
+	}
+	want := []string{
+		"test /a~1b", "remove /a~1b",
+		"test /model", "replace /model",
+		"test /permissions/allow", "remove /permissions/allow/2", "add /permissions/allow/0",
+		"add /permissions/defaultMode",
+	}
+	if strings.Join(kinds, ", ") != strings.Join(want, ", ") {
+		t.Errorf("ops:\ngot  %s\nwant %s", strings.Join(kinds, ", "), strings.Join(want, ", "))
+	}
+
+	got, err := ApplyJSONPatch([]byte(before), ops, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g, w any
+	if err := UnmarshalJSONC(got, &g); err != nil {
+		t.Fatalf("result doesn't parse: %v\n%s", err, got)
+	}
+	json.Unmarshal([]byte(after), &w)
+	gj, _ := json.Marshal(g)
+	wj, _ := json.Marshal(w)
+	if string(gj) != string(wj) {
+		t.Errorf("got %s, want %s", gj, wj)
+	}
+}
+
+func TestApplyJSONPatchRejects(t *testing.T) {
+	ops, err := ParseJSONPatch([]byte(`[
+  {"op": "test", "path": "/permissions/allow", "value": ["Read"]},
+  {"op": "add", "path": "/permissions/allow/-", "value": "Write"}
+]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ApplyJSONPatch([]byte(`{"permissions": {"allow": ["Read"]}}`), ops, false)
+	if err != nil || string(got) != `{"permissions": {"allow": ["Read", "Write"]}}` {
+		t.Errorf("got %s, %v", got, err)
+	}
+
+	_, err = ApplyJSONPatch([]byte(`{"permissions": {"allow": ["Bash"]}}`), ops, false)
+	var rej *RejectError
+	if !errors.As(err, &rej) || len(rej.Rejects) != 1 {
+		t.Fatalf("expected one reject, got %v", err)
+	}
+	if s := rej.Rejects[0].String(); s != `operation 1 (test /permissions/allow): expected ["Read"], found ["Bash"]` {
+		t.Errorf("got %q", s)
+	}
+}
+
+func TestApplyJSONPatchAddSetSince(t *testing.T) {
+	ops, err := DiffJSONPatch([]byte(`{"permissions": {}}`), []byte(`{"permissions": {"defaultMode": "plan"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ApplyJSONPatch([]byte(`{"permissions": {"defaultMode": "acceptEdits"}}`), ops, false)
+	var rej *RejectError
+	if !errors.As(err, &rej) || !strings.Contains(rejectText(err), `already set to "acceptEdits"`) {
+		t.Fatalf("expected a reject, got %v", err)
+	}
+
+	// Re-applying a patch that already took effect is fine.
+	got, err := ApplyJSONPatch([]byte(`{"permissions": {"defaultMode": "plan"}}`), ops, false)
+	if err != nil || string(got) != `{"permissions": {"defaultMode": "plan"}}` {
+		t.Errorf("got %s, %v", got, err)
+	}
+}
+
+func TestApplyJSONPatchOps(t *testing.T) {
+	doc := `{"a": {"b": [1, 2, 3]}, "c": "x"}`
+	for _, tc := range []struct {
+		name, patch, want, err string
+	}{
+		{"remove element", `[{"op":"remove","path":"/a/b/1"}]`, `{"a":{"b":[1,3]},"c":"x"}`, ""},
+		{"insert element", `[{"op":"add","path":"/a/b/0","value":0}]`, `{"a":{"b":[0,1,2,3]},"c":"x"}`, ""},
+		{"replace", `[{"op":"replace","path":"/c","value":"y"}]`, `{"a":{"b":[1,2,3]},"c":"y"}`, ""},
+		{"move", `[{"op":"move","from":"/c","path":"/a/c"}]`, `{"a":{"b":[1,2,3],"c":"x"}}`, ""},
+		{"copy", `[{"op":"copy","from":"/a/b","path":"/d"}]`, `{"a":{"b":[1,2,3]},"c":"x","d":[1,2,3]}`, ""},
+		{"test number", `[{"op":"test","path":"/a/b/0","value":1.0}]`, `{"a":{"b":[1,2,3]},"c":"x"}`, ""},
+		{"missing", `[{"op":"remove","path":"/nope"}]`, "", "/nope: doesn't exist"},
+		{"out of range", `[{"op":"add","path":"/a/b/5","value":0}]`, "", "out of range"},
+		{"bad index", `[{"op":"remove","path":"/a/b/01"}]`, "", "invalid array index"},
+		{"into itself", `[{"op":"move","from":"/a","path":"/a/b/x"}]`, "", "into itself"},
+		{"unknown op", `[{"op":"frob","path":"/a"}]`, "", "unknown op"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ops, err := ParseJSONPatch([]byte(tc.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ApplyJSONPatch([]byte(doc), ops, false)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(rejectText(err), tc.err) {
+					t.Errorf("got %v, want error containing %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(rejectText(err))
+			}
+			var v any
+			json.Unmarshal(got, &v)
+			compact, _ := json.Marshal(v)
+			if string(compact) != tc.want {
+				t.Errorf("got %s, want %s", compact, tc.want)
+			}
+		})
+	}
+}
+
+func rejectText(err error) string {
+	var rej *RejectError
+	if errors.As(err, &rej) {
+		var parts []string
+		for _, r := range rej.Rejects {
+			parts = append(parts, r.String())
+		}
+		return strings.Join(parts, "; ")
+	}
+	return err.Error()
+}
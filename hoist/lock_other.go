@@ -0,0 +1,32 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package hoist
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// lockFile creates name + ".lock" exclusively and removes it on unlock. A
+// lock file left behind by a crashed process has to be removed by hand.
+func lockFile(name string) (func(), error) {
+	lock := name + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("locking %s: %w", name, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock on %s (remove %s if no other claude-hoist is running)", name, lock)
+		}
+		time.Sleep(lockPoll)
+	}
+}
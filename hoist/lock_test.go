@@ -0,0 +1,29 @@
+package hoist
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOSFSLock(t *testing.T) {
+	defer func(d time.Duration) { lockTimeout = d }(lockTimeout)
+	lockTimeout = 100 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "settings.local.json")
+	unlock, err := OSFS{}.Lock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (OSFS{}).Lock(path); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("second lock: got %v", err)
+	}
+	unlock()
+
+	unlock, err = OSFS{}.Lock(path)
+	if err != nil {
+		t.Fatalf("after unlock: %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,94 @@
+package hoist
+
+import "strings"
+
+// MCPChanges is the MCP server enablement a project has that the user config lacks.
+type MCPChanges struct {
+	Enable  []string // servers to add to enabledMcpjsonServers
+	Disable []string // servers to add to disabledMcpjsonServers
+
+	// EnableAll is the project's enableAllProjectMcpServers when the user
+	// config doesn't set it. A differing user value is never overwritten.
+	EnableAll *bool
+	// EnableAllConflict is set when both configs set enableAllProjectMcpServers
+	// to different values.
+	EnableAllConflict bool
+}
+
+func (c MCPChanges) Empty() bool {
+	return len(c.Enable) == 0 && len(c.Disable) == 0 && c.EnableAll == nil
+}
+
+// DiffMCP returns the MCP enablement in project that user doesn't have yet.
+func DiffMCP(project, user Settings) MCPChanges {
+	c := MCPChanges{
+		Enable:  Diff(project.EnabledMcpjsonServers, user.EnabledMcpjsonServers),
+		Disable: Diff(project.DisabledMcpjsonServers, user.DisabledMcpjsonServers),
+	}
+	if p := project.EnableAllProjectMcpServers; p != nil {
+		switch u := user.EnableAllProjectMcpServers; {
+		case u == nil:
+			c.EnableAll = p
+		case *u != *p:
+			c.EnableAllConflict = true
+		}
+	}
+	return c
+}
+
+// MergeMCP applies MCP changes to user settings, keeping the order of the
+// servers it already lists.
+func MergeMCP(user Settings, c MCPChanges) Settings {
+	user.EnabledMcpjsonServers = mergeList(user.EnabledMcpjsonServers, c.Enable)
+	user.DisabledMcpjsonServers = mergeList(user.DisabledMcpjsonServers, c.Disable)
+	if c.EnableAll != nil && user.EnableAllProjectMcpServers == nil {
+		v := *c.EnableAll
+		user.EnableAllProjectMcpServers = &v
+	}
+	return user
+}
+
+// MCPServer returns the server a rule refers to, e.g. "github" for
+// "mcp__github__create_issue".
+func MCPServer(rule string) (string, bool) {
+	rest, ok := strings.CutPrefix(rule, "mcp__")
+	if !ok {
+		return "", false
+	}
+	server, _, _ := strings.Cut(rest, "__")
+	if server == "" {
+		return "", false
+	}
+	return server, true
+}
+
+// MCPServerEnabled reports whether s enables server, either by name or
+// through enableAllProjectMcpServers, without disabling it.
+func MCPServerEnabled(s Settings, server string) bool {
+	for _, d := range s.DisabledMcpjsonServers {
+		if d == server {
+			return false
+		}
+	}
+	if s.EnableAllProjectMcpServers != nil && *s.EnableAllProjectMcpServers {
+		return true
+	}
+	for _, e := range s.EnabledMcpjsonServers {
+		if e == server {
+			return true
+		}
+	}
+	return false
+}
+
+// RulesByServer groups MCP rules by the server they refer to.
+// Rules for other tools are left out.
+func RulesByServer(rules []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, r := range rules {
+		if server, ok := MCPServer(r); ok {
+			groups[server] = append(groups[server], r)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,65 @@
+package hoist
+
+import "testing"
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestDiffMCP(t *testing.T) {
+	project := Settings{
+		EnabledMcpjsonServers:      []string{"github", "linear"},
+		DisabledMcpjsonServers:     []string{"sketchy"},
+		EnableAllProjectMcpServers: boolPtr(true),
+	}
+	user := Settings{EnabledMcpjsonServers: []string{"linear"}}
+
+	c := DiffMCP(project, user)
+	if len(c.Enable) != 1 || c.Enable[0] != "github" {
+		t.Fatalf("enable: got %v", c.Enable)
+	}
+	if len(c.Disable) != 1 || c.Disable[0] != "sketchy" {
+		t.Fatalf("disable: got %v", c.Disable)
+	}
+	if c.EnableAll == nil || !*c.EnableAll || c.EnableAllConflict {
+		t.Fatalf("enableAll: got %v conflict %v", c.EnableAll, c.EnableAllConflict)
+	}
+
+	user.EnableAllProjectMcpServers = boolPtr(false)
+	c = DiffMCP(project, user)
+	if c.EnableAll != nil || !c.EnableAllConflict {
+		t.Fatalf("expected conflict, got %+v", c)
+	}
+
+	merged := MergeMCP(user, c)
+	if *merged.EnableAllProjectMcpServers {
+		t.Fatal("conflicting user value was overwritten")
+	}
+	if len(merged.EnabledMcpjsonServers) != 2 || merged.EnabledMcpjsonServers[0] != "github" {
+		t.Fatalf("merged enable: got %v", merged.EnabledMcpjsonServers)
+	}
+}
+
+func TestMCPServer(t *testing.T) {
+	tests := map[string]string{
+		"mcp__github__create_issue": "github",
+		"mcp__github":               "github",
+		"Bash(ls)":                  "",
+		"mcp__":                     "",
+	}
+	for rule, want := range tests {
+		got, ok := MCPServer(rule)
+		if got != want || ok != (want != "") {
+			t.Errorf("MCPServer(%q) = %q, %v; want %q", rule, got, ok, want)
+		}
+	}
+}
+
+func TestMCPServerEnabled(t *testing.T) {
+	s := Settings{EnabledMcpjsonServers: []string{"github"}, DisabledMcpjsonServers: []string{"linear"}}
+	if !MCPServerEnabled(s, "github") || MCPServerEnabled(s, "jira") {
+		t.Fatal("named enablement")
+	}
+	s.EnableAllProjectMcpServers = boolPtr(true)
+	if !MCPServerEnabled(s, "jira") || MCPServerEnabled(s, "linear") {
+		t.Fatal("enable-all should cover every server except disabled ones")
+	}
+}
@@ -0,0 +1,310 @@
+package hoist
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Patch is a unified diff of one file, as printed by UnifiedDiff or GNU
+// diff -u.
+type Patch struct {
+	OldName, NewName string
+	Hunks            []PatchHunk
+}
+
+// PatchHunk is one @@ section of a unified diff. Old and New hold the lines
+// it replaces and the lines it puts in their place, each with its newline
+// unless it ends the file without one.
+type PatchHunk struct {
+	OldStart, OldCount int // as in the header: 1-based, or the line before an empty range
+	NewStart, NewCount int
+	Old, New           []string
+}
+
+func (h PatchHunk) header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", patchRange(h.OldStart, h.OldCount), patchRange(h.NewStart, h.NewCount))
+}
+
+func patchRange(start, count int) string {
+	if count == 0 {
+		return hunkRange(start, 0)
+	}
+	return hunkRange(start-1, count)
+}
+
+// Reject says why part of a patch doesn't apply.
+type Reject struct {
+	Where string // the hunk or operation, e.g. "hunk 2 (@@ -4,7 +4,8 @@)"
+	Msg   string
+}
+
+func (r Reject) String() string {
+	return r.Where + ": " + r.Msg
+}
+
+// RejectError is returned when a patch doesn't apply. Nothing is changed.
+type RejectError struct {
+	Total   int // hunks or operations in the patch
+	Rejects []Reject
+}
+
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("patch does not apply: %d of %d rejected", len(e.Rejects), e.Total)
+}
+
+// IsJSONPatch reports whether data looks like a JSON Patch document rather
+// than a unified diff.
+func IsJSONPatch(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '['
+}
+
+// ParsePatch parses a unified diff of a single file. Lines before the ---
+// header, such as a commit message, are skipped.
+func ParsePatch(data []byte) (*Patch, error) {
+	var p Patch
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, 16<<20)
+	lineNo := 0
+	next := func() (string, bool) {
+		if !sc.Scan() {
+			return "", false
+		}
+		lineNo++
+		return sc.Text(), true
+	}
+
+	var line string
+	var ok bool
+	for {
+		if line, ok = next(); !ok {
+			return nil, errors.New("no unified diff found")
+		}
+		if strings.HasPrefix(line, "--- ") {
+			break
+		}
+	}
+	p.OldName = patchName(line[4:])
+	if line, ok = next(); !ok || !strings.HasPrefix(line, "+++ ") {
+		return nil, fmt.Errorf("line %d: expected +++ header", lineNo+1)
+	}
+	p.NewName = patchName(line[4:])
+
+	line, ok = next()
+	for ok {
+		if strings.HasPrefix(line, "--- ") {
+			return nil, fmt.Errorf("line %d: patch changes more than one file", lineNo)
+		}
+		if !strings.HasPrefix(line, "@@ ") {
+			line, ok = next()
+			continue
+		}
+		h, err := parseHunkHeader(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		last := &h.Old // the side the previous line went to, for "\ No newline"
+		noNewline := func() {
+			if last == nil {
+				trimLast(&h.Old)
+				trimLast(&h.New)
+			} else {
+				trimLast(last)
+			}
+		}
+		for len(h.Old) < h.OldCount || len(h.New) < h.NewCount {
+			if line, ok = next(); !ok {
+				break
+			}
+			if line == "" {
+				line = " " // some tools strip the space from empty context lines
+			}
+			switch line[0] {
+			case ' ':
+				h.Old = append(h.Old, line[1:]+"\n")
+				h.New = append(h.New, line[1:]+"\n")
+				last = nil
+			case '-':
+				h.Old = append(h.Old, line[1:]+"\n")
+				last = &h.Old
+			case '+':
+				h.New = append(h.New, line[1:]+"\n")
+				last = &h.New
+			case '\\':
+				noNewline()
+			default:
+				return nil, fmt.Errorf("line %d: unexpected %q in hunk", lineNo, line)
+			}
+		}
+		// A "\ No newline" may follow the last counted line.
+		if line, ok = next(); ok && strings.HasPrefix(line, `\`) {
+			noNewline()
+			line, ok = next()
+		}
+		if len(h.Old) != h.OldCount || len(h.New) != h.NewCount {
+			return nil, fmt.Errorf("hunk %s: expected %d old and %d new lines, found %d and %d",
+				h.header(), h.OldCount, h.NewCount, len(h.Old), len(h.New))
+		}
+		p.Hunks = append(p.Hunks, h)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Hunks) == 0 {
+		return nil, errors.New("diff has no hunks")
+	}
+	return &p, nil
+}
+
+// patchName strips the timestamp GNU diff appends after a tab.
+func patchName(s string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+func trimLast(lines *[]string) {
+	if n := len(*lines); n > 0 {
+		(*lines)[n-1] = strings.TrimSuffix((*lines)[n-1], "\n")
+	}
+}
+
+func parseHunkHeader(line string) (PatchHunk, error) {
+	var h PatchHunk
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[3] != "@@" || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return h, fmt.Errorf("malformed hunk header %q", line)
+	}
+	var err error
+	if h.OldStart, h.OldCount, err = parseRange(fields[1][1:]); err != nil {
+		return h, fmt.Errorf("malformed hunk header %q", line)
+	}
+	if h.NewStart, h.NewCount, err = parseRange(fields[2][1:]); err != nil {
+		return h, fmt.Errorf("malformed hunk header %q", line)
+	}
+	return h, nil
+}
+
+func parseRange(s string) (start, count int, err error) {
+	count = 1
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		if count, err = strconv.Atoi(s[i+1:]); err != nil {
+			return 0, 0, err
+		}
+		s = s[:i]
+	}
+	start, err = strconv.Atoi(s)
+	return start, count, err
+}
+
+// Apply returns content with the patch applied. Every hunk must find its
+// context and removed lines exactly where the header says; otherwise the
+// result is a *RejectError listing each hunk that doesn't match.
+func (p *Patch) Apply(content []byte) ([]byte, error) {
+	lines := splitLines(string(content))
+	var out []string
+	var rejects []Reject
+	pos := 0
+	for i, h := range p.Hunks {
+		where := fmt.Sprintf("hunk %d (%s)", i+1, h.header())
+		start := h.OldStart - 1
+		if h.OldCount == 0 {
+			start = h.OldStart // an empty range names the line before it
+		}
+		if start < pos {
+			rejects = append(rejects, Reject{Where: where, Msg: "overlaps the previous hunk"})
+			continue
+		}
+		if start+len(h.Old) > len(lines) {
+			rejects = append(rejects, Reject{Where: where, Msg: fmt.Sprintf("lines %d-%d are past the end of the file (%d lines)", start+1, start+len(h.Old), len(lines))})
+			continue
+		}
+		if msg := mismatch(lines[start:start+len(h.Old)], h.Old, start); msg != "" {
+			rejects = append(rejects, Reject{Where: where, Msg: msg})
+			continue
+		}
+		out = append(out, lines[pos:start]...)
+		out = append(out, h.New...)
+		pos = start + len(h.Old)
+	}
+	if len(rejects) > 0 {
+		return nil, &RejectError{Total: len(p.Hunks), Rejects: rejects}
+	}
+	out = append(out, lines[pos:]...)
+	return []byte(strings.Join(out, "")), nil
+}
+
+// mismatch describes the first line where have differs from want, or "".
+func mismatch(have, want []string, offset int) string {
+	for i := range want {
+		if have[i] == want[i] {
+			continue
+		}
+		h, w := strings.TrimSuffix(have[i], "\n"), strings.TrimSuffix(want[i], "\n")
+		if h == w {
+			return fmt.Sprintf("line %d: newline at end of file differs", offset+i+1)
+		}
+		return fmt.Sprintf("line %d: expected %q, found %q", offset+i+1, w, h)
+	}
+	return ""
+}
+
+// WriteFileAtomic replaces path with data by writing a temporary file next
+// to it and renaming it into place, so readers never see a partial file. An
+// existing file keeps its permissions; a new one gets perm. When path is a
+// symlink, the file it points to is replaced and the link is kept.
+func WriteFileAtomic(path string, data []byte, perm fs.FileMode) error {
+	path = ResolveSymlinks(path)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ResolveSymlinks returns the file path ultimately points to, or path itself
+// when it isn't a symlink. Unlike filepath.EvalSymlinks it also resolves a
+// link whose target doesn't exist yet, so writing through it creates the
+// target instead of replacing the link.
+func ResolveSymlinks(path string) string {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		return target
+	}
+	for range 40 {
+		info, err := os.Lstat(path)
+		if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+			return path
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			return path
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = target
+	}
+	return path
+}
@@ -0,0 +1,134 @@
+package hoist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Every diff UnifiedDiff prints must apply back to the file it came from.
+func TestPatchRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("testdata/udiff/*.a")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no fixtures: %v", err)
+	}
+	for _, fa := range files {
+		name := strings.TrimSuffix(filepath.Base(fa), ".a")
+		t.Run(name, func(t *testing.T) {
+			a, _ := os.ReadFile(fa)
+			b, _ := os.ReadFile(strings.TrimSuffix(fa, ".a") + ".b")
+			d := UnifiedDiff("a", "b", string(a), string(b))
+			if d == "" {
+				t.Skip("identical")
+			}
+			p, err := ParsePatch([]byte(d))
+			if err != nil {
+				t.Fatalf("parse: %v\n%s", err, d)
+			}
+			got, err := p.Apply(a)
+			if err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			if string(got) != string(b) {
+				t.Errorf("got:\n%q\nwant:\n%q", got, b)
+			}
+		})
+	}
+}
+
+func TestPatchRejects(t *testing.T) {
+	a := "{\n  \"permissions\": {\n    \"allow\": [\n      \"Read\"\n    ]\n  }\n}\n"
+	b := "{\n  \"permissions\": {\n    \"allow\": [\n      \"Read\",\n      \"Write\"\n    ]\n  }\n}\n"
+	p, err := ParsePatch([]byte(UnifiedDiff("settings.json", "settings.json", a, b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.OldName != "settings.json" || len(p.Hunks) != 1 {
+		t.Fatalf("got %+v", p)
+	}
+
+	changed := strings.Replace(a, "Read", "Bash", 1)
+	_, err = p.Apply([]byte(changed))
+	var rej *RejectError
+	if !errors.As(err, &rej) {
+		t.Fatalf("expected *RejectError, got %v", err)
+	}
+	if len(rej.Rejects) != 1 || !strings.Contains(rej.Rejects[0].String(), `line 4: expected "      \"Read\"", found "      \"Bash\""`) {
+		t.Errorf("got %v", rej.Rejects)
+	}
+}
+
+func TestParsePatchErrors(t *testing.T) {
+	for _, tc := range []struct{ name, patch, want string }{
+		{"no diff", "hello\n", "no unified diff"},
+		{"no hunks", "--- a\n+++ b\n", "no hunks"},
+		{"short hunk", "--- a\n+++ b\n@@ -1,2 +1,2 @@\n x\n", "expected 2 old"},
+		{"two files", "--- a\n+++ a\n@@ -1 +1 @@\n-x\n+y\n--- b\n+++ b\n", "more than one file"},
+		{"bad line", "--- a\n+++ b\n@@ -1 +1 @@\n*x\n", "unexpected"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParsePatch([]byte(tc.patch))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParsePatchSkipsPreamble(t *testing.T) {
+	patch := "Add Write\n\ndiff -u a b\n--- a\t2024-01-01 00:00:00\n+++ b\t2024-01-01 00:00:01\n@@ -1 +1,2 @@\n x\n+y\n"
+	p, err := ParsePatch([]byte(patch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.OldName != "a" || p.NewName != "b" {
+		t.Errorf("names: %q %q", p.OldName, p.NewName)
+	}
+	got, err := p.Apply([]byte("x\n"))
+	if err != nil || string(got) != "x\ny\n" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := WriteFileAtomic(path, []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Chmod(path, 0640)
+	if err := WriteFileAtomic(path, []byte("[]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := os.Stat(path)
+	data, _ := os.ReadFile(path)
+	if string(data) != "[]\n" || info.Mode().Perm() != 0640 {
+		t.Errorf("got %q with mode %v", data, info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("temporary file left behind: %v", entries)
+	}
+}
+
+func TestWriteFileAtomicSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "settings.json")
+	link := filepath.Join(dir, "settings.json")
+	os.Mkdir(filepath.Dir(target), 0700)
+	// A dangling link is followed too, creating its target.
+	if err := os.Symlink("dotfiles/settings.json", link); err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range []string{"{}\n", "[]\n"} {
+		if err := WriteFileAtomic(link, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Fatalf("link replaced: %v %v", info, err)
+		}
+		if data, _ := os.ReadFile(target); string(data) != content {
+			t.Errorf("target has %q, want %q", data, content)
+		}
+	}
+}
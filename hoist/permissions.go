@@ -1,20 +1,63 @@
 package hoist
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 )
 
 type Permissions struct {
 	Allow []string `json:"allow,omitempty"`
+	Ask   []string `json:"ask,omitempty"`
 	Deny  []string `json:"deny,omitempty"`
+
+	AdditionalDirectories []string `json:"additionalDirectories,omitempty"`
+	DefaultMode           string   `json:"defaultMode,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // keys claude-hoist doesn't model
+}
+
+// List names one of the rule lists in Permissions.
+type List string
+
+const (
+	ListAllow List = "allow"
+	ListAsk   List = "ask"
+	ListDeny  List = "deny"
+)
+
+// Lists is every rule list in evaluation order: deny beats ask beats allow.
+var Lists = []List{ListDeny, ListAsk, ListAllow}
+
+// Rules returns the rules in the given list.
+func (p Permissions) Rules(l List) []string {
+	switch l {
+	case ListAllow:
+		return p.Allow
+	case ListAsk:
+		return p.Ask
+	case ListDeny:
+		return p.Deny
+	}
+	return nil
 }
 
 type Settings struct {
-	Permissions Permissions `json:"permissions"`
+	Permissions Permissions `json:"permissions,omitzero"`
+
+	EnabledMcpjsonServers      []string `json:"enabledMcpjsonServers,omitempty"`
+	DisabledMcpjsonServers     []string `json:"disabledMcpjsonServers,omitempty"`
+	EnableAllProjectMcpServers *bool    `json:"enableAllProjectMcpServers,omitempty"`
+
+	Hooks map[string][]HookMatcher `json:"hooks,omitempty"`
+	Env   map[string]string        `json:"env,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // keys claude-hoist doesn't model
 }
 
 func FindProjectSettings() (string, error) {
@@ -37,27 +80,72 @@ func UserSettingsPath() (string, error) {
 	return filepath.Join(home, ".claude", "settings.local.json"), nil
 }
 
+// ReadSettings reads a settings file. Comments and trailing commas are
+// tolerated; malformed input is reported as a *SyntaxError.
 func ReadSettings(path string) (Settings, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return Settings{}, err
 	}
+	return parseSettings(path, data)
+}
+
+func parseSettings(path string, data []byte) (Settings, error) {
 	var s Settings
-	if err := json.Unmarshal(data, &s); err != nil {
+	if err := UnmarshalJSONC(data, &s); err != nil {
+		var syn *SyntaxError
+		if errors.As(err, &syn) {
+			syn.Path = path
+			return Settings{}, syn
+		}
 		return Settings{}, fmt.Errorf("parsing %s: %w", path, err)
 	}
 	return s, nil
 }
 
+// WriteSettings writes s to path. An existing file is edited in place so
+// untouched lines, key order and indentation stay as they were.
 func WriteSettings(path string, s Settings) error {
-	data, err := json.MarshalIndent(s, "", "  ")
+	_, data, err := EncodeSettings(path, s)
 	if err != nil {
 		return err
 	}
-	data = append(data, '\n')
 	return os.WriteFile(path, data, 0600)
 }
 
+// EncodeSettings returns the current content of path and the content
+// WriteSettings would replace it with. When the file parses, the new content
+// is a minimal edit of it, with comments and trailing commas removed;
+// otherwise s is freshly indented. Use a Client with Options.KeepComments to
+// keep comments.
+func EncodeSettings(path string, s Settings) (before, after []byte, err error) {
+	before, err = os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	after, err = encodeSettings(before, s, false)
+	return before, after, err
+}
+
+func encodeSettings(before []byte, s Settings, keepComments bool) ([]byte, error) {
+	if len(bytes.TrimSpace(before)) > 0 {
+		base := before
+		if !keepComments {
+			if stripped, err := StripJSONC(before); err == nil {
+				base = stripped
+			}
+		}
+		if after, err := EditJSON(base, s); err == nil {
+			return after, nil
+		}
+	}
+	after, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(after, '\n'), nil
+}
+
 // Diff returns items in source that are not in target.
 func Diff(source, target []string) []string {
 	have := make(map[string]bool, len(target))
@@ -73,14 +161,47 @@ func Diff(source, target []string) []string {
 	return result
 }
 
+// Merge adds rules to the user config's allow and deny lists. Existing rules
+// keep their order; see mergeList.
 func Merge(user Settings, newAllow, newDeny []string) Settings {
-	user.Permissions.Allow = dedup(append(user.Permissions.Allow, newAllow...))
-	user.Permissions.Deny = dedup(append(user.Permissions.Deny, newDeny...))
-	sort.Strings(user.Permissions.Allow)
-	sort.Strings(user.Permissions.Deny)
+	user = MergeRules(user, ListAllow, newAllow)
+	return MergeRules(user, ListDeny, newDeny)
+}
+
+// MergeRules adds rules to one of the user config's permission lists, like
+// Merge.
+func MergeRules(user Settings, list List, rules []string) Settings {
+	switch list {
+	case ListAllow:
+		user.Permissions.Allow = mergeList(user.Permissions.Allow, rules)
+	case ListAsk:
+		user.Permissions.Ask = mergeList(user.Permissions.Ask, rules)
+	case ListDeny:
+		user.Permissions.Deny = mergeList(user.Permissions.Deny, rules)
+	}
 	return user
 }
 
+// mergeList returns list with the items of add it lacks. list keeps its
+// order: when it is sorted, new items are inserted where they sort, and
+// otherwise they are appended. list itself is not modified.
+func mergeList(list, add []string) []string {
+	sorted := sort.StringsAreSorted(list)
+	result := append([]string(nil), list...)
+	for _, v := range add {
+		if contains(result, v) {
+			continue
+		}
+		if !sorted {
+			result = append(result, v)
+			continue
+		}
+		i := sort.SearchStrings(result, v)
+		result = slices.Insert(result, i, v)
+	}
+	return result
+}
+
 func dedup(items []string) []string {
 	seen := make(map[string]bool, len(items))
 	var result []string
@@ -94,32 +215,17 @@ func dedup(items []string) []string {
 }
 
 // LoadBoth reads project and user settings, returning both plus the computed diffs.
+//
+// Deprecated: use Client.Plan, which doesn't depend on the working directory
+// and home directory of the process.
 func LoadBoth() (project, user Settings, userPath string, newAllow, newDeny []string, err error) {
-	projectPath, err := FindProjectSettings()
+	c, err := New(Options{})
 	if err != nil {
 		return Settings{}, Settings{}, "", nil, nil, err
 	}
-
-	userPath, err = UserSettingsPath()
+	p, err := c.Plan(PlanOptions{Rules: true})
 	if err != nil {
 		return Settings{}, Settings{}, "", nil, nil, err
 	}
-
-	project, err = ReadSettings(projectPath)
-	if err != nil {
-		return Settings{}, Settings{}, "", nil, nil, fmt.Errorf("reading project settings: %w", err)
-	}
-
-	user, err = ReadSettings(userPath)
-	if err != nil && !os.IsNotExist(err) {
-		return Settings{}, Settings{}, "", nil, nil, fmt.Errorf("reading user settings: %w", err)
-	}
-	if os.IsNotExist(err) {
-		user = Settings{}
-	}
-
-	newAllow = Diff(project.Permissions.Allow, user.Permissions.Allow)
-	newDeny = Diff(project.Permissions.Deny, user.Permissions.Deny)
-
-	return project, user, userPath, newAllow, newDeny, nil
+	return p.Project, p.User, p.UserPath, p.Allow, p.Deny, nil
 }
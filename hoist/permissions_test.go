@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -100,6 +101,22 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeKeepsOrder(t *testing.T) {
+	user := Settings{
+		Permissions: Permissions{
+			Allow: []string{"WebSearch", "Bash(ls:*)", "WebSearch"},
+		},
+	}
+	got := Merge(user, []string{"Read", "Bash(ls:*)"}, nil)
+	want := []string{"WebSearch", "Bash(ls:*)", "WebSearch", "Read"}
+	if strings.Join(got.Permissions.Allow, ",") != strings.Join(want, ",") {
+		t.Fatalf("allow: got %v, want %v", got.Permissions.Allow, want)
+	}
+	if user.Permissions.Allow[0] != "WebSearch" || len(user.Permissions.Allow) != 3 {
+		t.Fatalf("Merge modified the input settings: %v", user.Permissions.Allow)
+	}
+}
+
 func TestMergeEmptyUser(t *testing.T) {
 	user := Settings{}
 	newAllow := []string{"Bash(ls:*)", "WebSearch"}
@@ -191,3 +208,43 @@ func TestWriteSettingsProducesValidJSON(t *testing.T) {
 		t.Fatal("roundtrip failed")
 	}
 }
+
+func TestSettingsPreservesUnknownKeys(t *testing.T) {
+	input := `{"model":"opus","permissions":{"allow":["Read"],"defaultMode":"plan"},"env":{"A":"1"}}`
+
+	var s Settings
+	if err := json.Unmarshal([]byte(input), &s); err != nil {
+		t.Fatal(err)
+	}
+	s = Merge(s, []string{"WebSearch"}, nil)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"permissions":{"allow":["Read","WebSearch"],"defaultMode":"plan"},"env":{"A":"1"},"model":"opus"}`
+	if string(data) != want {
+		t.Fatalf("got  %s\nwant %s", data, want)
+	}
+}
+
+func TestWriteSettingsKeepsLayout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.local.json")
+	original := "{\n    \"model\": \"opus\",\n    \"permissions\": {\n        \"allow\": [\"Read\",\n                  \"WebSearch\"]\n    }\n}"
+	os.WriteFile(path, []byte(original), 0600)
+
+	s, err := ReadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSettings(path, Merge(s, []string{"Bash(ls:*)"}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := "{\n    \"model\": \"opus\",\n    \"permissions\": {\n        \"allow\": [\"Bash(ls:*)\",\n                  \"Read\",\n                  \"WebSearch\"]\n    }\n}"
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
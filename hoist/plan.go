@@ -0,0 +1,239 @@
+package hoist
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+)
+
+// PlanOptions selects what a Plan hoists from the project to the user config.
+type PlanOptions struct {
+	Filter Filter // narrows the rules; the zero value keeps every rule
+	Rules  bool   // allow, ask and deny rules
+	MCP    bool   // MCP server enablement
+	Dirs   bool   // additional directories
+	Mode   bool   // defaultMode
+	Env    bool   // env variables
+	Hooks  bool   // hooks
+
+	// SharedHooks also hoists the hooks in the project's committed
+	// settings.json, and lets settings.local.json be missing.
+	SharedHooks bool
+
+	SkipDismissed bool // leave out rules dismissed in the review queue
+}
+
+// Plan is the set of changes hoisting would make to the user config. Client.Plan
+// computes it and Client.Apply writes it; in between, callers may drop the
+// changes they don't want.
+type Plan struct {
+	ProjectPath string
+	UserPath    string
+	ManagedPath string
+	ProjectHash string // SHA-256 of the project file as read
+	UserHash    string // SHA-256 of the user file as read, or "" when it didn't exist
+	Project     Settings
+	User        Settings
+	Managed     Settings
+
+	Allow   []string          // new allow rules, including blocked ones
+	Ask     []string          // new ask rules
+	Deny    []string          // new deny rules
+	Blocked map[string]string // allow rules a managed deny rule forbids, mapped to that rule; never written
+
+	MCP         MCPChanges
+	Dirs        []DirChange   // missing directories are never written
+	Mode        *ScalarChange // a differing defaultMode, or nil
+	ReplaceMode bool          // replace the user's defaultMode when Mode conflicts with it
+	Env         []EnvChange   // written as they stand: drop secrets and unresolved conflicts first
+	Hooks       []HookChange
+}
+
+// Allowed returns the new allow rules that aren't blocked.
+func (p *Plan) Allowed() []string {
+	var ok []string
+	for _, r := range p.Allow {
+		if _, blocked := p.Blocked[r]; !blocked {
+			ok = append(ok, r)
+		}
+	}
+	return ok
+}
+
+// Empty reports whether applying the plan would change nothing, apart from
+// conflicts it only reports.
+func (p *Plan) Empty() bool {
+	return len(p.Allowed()) == 0 && len(p.Ask) == 0 && len(p.Deny) == 0 &&
+		p.MCP.Empty() && !p.MCP.EnableAllConflict &&
+		!slices.ContainsFunc(p.Dirs, func(d DirChange) bool { return !d.Missing }) &&
+		p.Mode == nil && len(p.Env) == 0 && len(p.Hooks) == 0
+}
+
+// Settings returns the user config with the plan applied.
+func (p *Plan) Settings() Settings {
+	s := Merge(p.User, p.Allowed(), p.Deny)
+	s = MergeRules(s, ListAsk, p.Ask)
+	s = MergeMCP(s, p.MCP)
+	s = MergeDirs(s, p.Dirs)
+	if p.Mode != nil && (!p.Mode.Conflict() || p.ReplaceMode) {
+		s.Permissions.DefaultMode = p.Mode.To
+	}
+	s = MergeEnv(s, p.Env)
+	if len(p.Hooks) > 0 {
+		s = MergeHooks(s, p.Hooks)
+	}
+	return s
+}
+
+// Plan reads the project, user and managed settings and computes what
+// hoisting would change. The project's settings.local.json must exist,
+// unless opts.SharedHooks is set and settings.json does; the user's may not.
+func (c *Client) Plan(opts PlanOptions) (*Plan, error) {
+	p := &Plan{
+		ProjectPath: c.Path(ScopeProjectLocal),
+		UserPath:    c.Path(ScopeUserLocal),
+	}
+
+	var shared *Settings
+	if opts.SharedHooks {
+		s, err := c.ReadSettings(c.Path(ScopeProjectShared))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("reading project settings: %w", err)
+		}
+		if err == nil {
+			shared = &s
+		}
+	}
+
+	data, err := c.opts.FS.ReadFile(p.ProjectPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && shared != nil:
+		err = nil
+	case errors.Is(err, fs.ErrNotExist) && opts.SharedHooks:
+		return nil, fmt.Errorf("no .claude/settings.local.json or .claude/settings.json in %s", c.opts.ProjectDir)
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("no .claude/settings.local.json in %s", c.opts.ProjectDir)
+	case err == nil:
+		p.ProjectHash = hashFile(data)
+		p.Project, err = parseSettings(p.ProjectPath, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading project settings: %w", err)
+	}
+	data, err = c.opts.FS.ReadFile(p.UserPath)
+	if err == nil {
+		p.UserHash = hashFile(data)
+		p.User, err = parseSettings(p.UserPath, data)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading user settings: %w", err)
+	}
+	if p.Managed, p.ManagedPath, err = c.LoadManaged(); err != nil {
+		return nil, err
+	}
+
+	if opts.Rules {
+		p.Allow = opts.Filter.Apply(ListAllow, Diff(p.Project.Permissions.Allow, p.User.Permissions.Allow))
+		p.Ask = opts.Filter.Apply(ListAsk, Diff(p.Project.Permissions.Ask, p.User.Permissions.Ask))
+		p.Deny = opts.Filter.Apply(ListDeny, Diff(p.Project.Permissions.Deny, p.User.Permissions.Deny))
+		if opts.SkipDismissed {
+			q, err := c.LoadQueue()
+			if err != nil {
+				return nil, fmt.Errorf("reading the review queue: %w", err)
+			}
+			p.Allow = slices.DeleteFunc(p.Allow, func(r string) bool { return q.IsDismissed(ListAllow, r) })
+			p.Ask = slices.DeleteFunc(p.Ask, func(r string) bool { return q.IsDismissed(ListAsk, r) })
+			p.Deny = slices.DeleteFunc(p.Deny, func(r string) bool { return q.IsDismissed(ListDeny, r) })
+		}
+		_, p.Blocked = Blocked(p.Allow, p.Managed)
+	}
+	if opts.MCP {
+		p.MCP = DiffMCP(p.Project, p.User)
+	}
+	if opts.Dirs {
+		p.Dirs = diffDirs(p.Project, p.User, c.opts.Home, c.opts.ProjectDir, c.opts.FS.Stat)
+	}
+	if opts.Mode {
+		p.Mode = DiffDefaultMode(p.Project, p.User)
+	}
+	if opts.Env {
+		p.Env = DiffEnv(p.Project, p.User)
+	}
+	if opts.Hooks {
+		project := p.Project
+		if shared != nil {
+			project = Settings{Hooks: joinHooks(p.Project.Hooks, shared.Hooks)}
+		}
+		p.Hooks = DiffHooks(project, p.User)
+	}
+	return p, nil
+}
+
+// Preview returns the user config's current content and its content once
+// the plan is applied.
+func (c *Client) Preview(p *Plan) (before, after []byte, err error) {
+	return c.Encode(p.UserPath, p.Settings())
+}
+
+// Apply writes the plan to the user config while holding its lock. The file
+// is read again first: if something else, such as Claude Code approving a
+// prompt, changed it since the plan was computed, the plan is rebased onto
+// the new content instead of overwriting it, and rebased is true. Afterwards
+// the plan's User is the config as written.
+func (c *Client) Apply(p *Plan) (rebased bool, err error) {
+	unlock, err := c.Lock(p.UserPath)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	data, err := c.opts.FS.ReadFile(p.UserPath)
+	var hash string
+	switch {
+	case err == nil:
+		hash = hashFile(data)
+	case !errors.Is(err, fs.ErrNotExist):
+		return false, fmt.Errorf("reading user settings: %w", err)
+	}
+	if hash != p.UserHash {
+		var current Settings
+		if data != nil {
+			if current, err = parseSettings(p.UserPath, data); err != nil {
+				return false, err
+			}
+		}
+		p.rebase(current, hash)
+		rebased = true
+	}
+	s := p.Settings()
+	_, out, err := c.Encode(p.UserPath, s)
+	if err != nil {
+		return rebased, err
+	}
+	if err := c.opts.FS.WriteFile(p.UserPath, out, 0600); err != nil {
+		return rebased, err
+	}
+	p.User, p.UserHash = s, hashFile(out)
+	return rebased, nil
+}
+
+// rebase moves the plan onto the user config's current content. Where the
+// concurrent change and the plan touch the same setting, the concurrent
+// change wins: a defaultMode or env value it set is no longer replaced.
+func (p *Plan) rebase(current Settings, hash string) {
+	if m := p.Mode; m != nil && current.Permissions.DefaultMode != m.From {
+		p.Mode = &ScalarChange{Key: m.Key, From: current.Permissions.DefaultMode, To: m.To}
+		p.ReplaceMode = false
+	}
+	var env []EnvChange
+	for _, c := range p.Env {
+		was, wasSet := p.User.Env[c.Key]
+		now, nowSet := current.Env[c.Key]
+		if was == now && wasSet == nowSet {
+			env = append(env, c)
+		}
+	}
+	p.Env = env
+	p.User, p.UserHash = current, hash
+}
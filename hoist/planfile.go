@@ -0,0 +1,191 @@
+package hoist
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// planFormat marks a saved plan so apply can tell it from a JSON Patch.
+const planFormat = "claude-hoist-plan"
+
+// ErrStalePlan is returned when a file a saved plan was computed from has
+// changed since.
+var ErrStalePlan = errors.New("changed since the plan was made")
+
+// PlanFile is a Plan saved for review. It records the files it was computed
+// from by content hash and the exact changes to write, so applying it later
+// writes what was reviewed or nothing at all.
+type PlanFile struct {
+	Format  string      `json:"format"`
+	Version int         `json:"version"`
+	Created time.Time   `json:"created"`
+	Source  PlanTarget  `json:"source"`
+	Target  PlanTarget  `json:"target"`
+	Changes PlanChanges `json:"changes"`
+}
+
+// PlanTarget is a file a plan reads, with the SHA-256 of its content.
+// The hash is empty when the file didn't exist.
+type PlanTarget struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// PlanChanges are the changes a saved plan writes to the target. Only the
+// changes that would be written are recorded: blocked rules, missing
+// directories and a defaultMode that isn't replaced are left out.
+type PlanChanges struct {
+	Allow      []string          `json:"allow,omitempty"`
+	Ask        []string          `json:"ask,omitempty"`
+	Deny       []string          `json:"deny,omitempty"`
+	EnableMCP  []string          `json:"enabledMcpjsonServers,omitempty"`
+	DisableMCP []string          `json:"disabledMcpjsonServers,omitempty"`
+	EnableAll  *bool             `json:"enableAllProjectMcpServers,omitempty"`
+	Dirs       []string          `json:"additionalDirectories,omitempty"`
+	Mode       string            `json:"defaultMode,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+	Hooks      []PlanHook        `json:"hooks,omitempty"`
+}
+
+// PlanHook is a hook a saved plan adds.
+type PlanHook struct {
+	Event   string      `json:"event"`
+	Matcher string      `json:"matcher,omitempty"`
+	Hook    HookCommand `json:"hook"`
+}
+
+// hashFile returns the hex SHA-256 of data.
+func hashFile(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// File returns the plan in the form it is saved in.
+func (p *Plan) File() PlanFile {
+	f := PlanFile{
+		Format:  planFormat,
+		Version: 1,
+		Created: time.Now().UTC().Truncate(time.Second),
+		Source:  PlanTarget{Path: p.ProjectPath, SHA256: p.ProjectHash},
+		Target:  PlanTarget{Path: p.UserPath, SHA256: p.UserHash},
+		Changes: PlanChanges{
+			Allow:      p.Allowed(),
+			Ask:        p.Ask,
+			Deny:       p.Deny,
+			EnableMCP:  p.MCP.Enable,
+			DisableMCP: p.MCP.Disable,
+			EnableAll:  p.MCP.EnableAll,
+		},
+	}
+	for _, d := range p.Dirs {
+		if !d.Missing {
+			f.Changes.Dirs = append(f.Changes.Dirs, d.Path)
+		}
+	}
+	if p.Mode != nil && (!p.Mode.Conflict() || p.ReplaceMode) {
+		f.Changes.Mode = p.Mode.To
+	}
+	for _, c := range p.Env {
+		if f.Changes.Env == nil {
+			f.Changes.Env = make(map[string]string, len(p.Env))
+		}
+		f.Changes.Env[c.Key] = c.Value
+	}
+	for _, h := range p.Hooks {
+		f.Changes.Hooks = append(f.Changes.Hooks, PlanHook{Event: h.Event, Matcher: h.Matcher, Hook: h.Hook})
+	}
+	return f
+}
+
+// IsPlanFile reports whether data looks like a saved plan rather than a
+// diff or JSON Patch.
+func IsPlanFile(data []byte) bool {
+	var head struct {
+		Format string `json:"format"`
+	}
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{' && json.Unmarshal(data, &head) == nil && head.Format == planFormat
+}
+
+// ParsePlanFile decodes a saved plan.
+func ParsePlanFile(data []byte) (*PlanFile, error) {
+	var f PlanFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	if f.Format != planFormat {
+		return nil, fmt.Errorf("not a claude-hoist plan")
+	}
+	if f.Version != 1 {
+		return nil, fmt.Errorf("unsupported plan version %d", f.Version)
+	}
+	if f.Source.Path == "" || f.Target.Path == "" {
+		return nil, fmt.Errorf("plan has no source or target path")
+	}
+	return &f, nil
+}
+
+// LoadPlan turns a saved plan back into a Plan for Apply or Preview. It
+// fails with ErrStalePlan when the source or target no longer has the
+// content the plan was computed from.
+func (c *Client) LoadPlan(f *PlanFile) (*Plan, error) {
+	if _, err := c.checkPlanTarget(f.Source); err != nil {
+		return nil, err
+	}
+	data, err := c.checkPlanTarget(f.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{
+		ProjectPath: f.Source.Path,
+		UserPath:    f.Target.Path,
+		ProjectHash: f.Source.SHA256,
+		UserHash:    f.Target.SHA256,
+		Allow:       f.Changes.Allow,
+		Ask:         f.Changes.Ask,
+		Deny:        f.Changes.Deny,
+		MCP:         MCPChanges{Enable: f.Changes.EnableMCP, Disable: f.Changes.DisableMCP, EnableAll: f.Changes.EnableAll},
+	}
+	if data != nil {
+		if p.User, err = parseSettings(p.UserPath, data); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range f.Changes.Dirs {
+		p.Dirs = append(p.Dirs, DirChange{Dir: d, Path: d})
+	}
+	if f.Changes.Mode != "" {
+		p.Mode = &ScalarChange{Key: "defaultMode", From: p.User.Permissions.DefaultMode, To: f.Changes.Mode}
+		p.ReplaceMode = true
+	}
+	for k, v := range f.Changes.Env {
+		p.Env = append(p.Env, EnvChange{Key: k, Value: v})
+	}
+	for _, h := range f.Changes.Hooks {
+		p.Hooks = append(p.Hooks, HookChange{Event: h.Event, Matcher: h.Matcher, Hook: h.Hook})
+	}
+	return p, nil
+}
+
+// checkPlanTarget reads a file a plan was computed from and checks it still
+// has the recorded content. It returns nil data for a file that still
+// doesn't exist.
+func (c *Client) checkPlanTarget(t PlanTarget) ([]byte, error) {
+	data, err := c.opts.FS.ReadFile(t.Path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && t.SHA256 == "":
+		return nil, nil
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	case err != nil || hashFile(data) != t.SHA256:
+		return nil, fmt.Errorf("%s: %w", t.Path, ErrStalePlan)
+	}
+	return data, nil
+}
@@ -0,0 +1,98 @@
+package hoist
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPlanFileRoundTrip(t *testing.T) {
+	c, mem := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{
+  "permissions": {"allow": ["Bash(go test:*)", "Bash(curl:*)"], "deny": ["Bash(rm:*)"], "defaultMode": "plan"},
+  "env": {"GOFLAGS": "-mod=mod"}
+}`,
+		"/home/me/.claude/settings.local.json": `{"permissions": {"allow": ["Read"], "defaultMode": "acceptEdits"}}`,
+		"/etc/managed.json":                    `{"permissions": {"deny": ["Bash(curl:*)"]}}`,
+	})
+	plan, err := c.Plan(PlanOptions{Rules: true, Mode: true, Env: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, want, err := c.Preview(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(plan.File())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParsePlanFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPlanFile(data) || IsPlanFile([]byte(`[]`)) {
+		t.Error("IsPlanFile")
+	}
+	if strings.Join(f.Changes.Allow, ",") != "Bash(go test:*)" || f.Changes.Mode != "" {
+		t.Errorf("blocked rules and unconfirmed mode must be left out: %+v", f.Changes)
+	}
+	if len(f.Source.SHA256) != 64 || len(f.Target.SHA256) != 64 {
+		t.Errorf("hashes: %+v %+v", f.Source, f.Target)
+	}
+
+	loaded, err := c.LoadPlan(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Apply(loaded); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := mem.ReadFile(plan.UserPath)
+	if string(got) != string(want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The target has changed now, so the same plan no longer applies.
+	if _, err := c.LoadPlan(f); !errors.Is(err, ErrStalePlan) {
+		t.Errorf("stale target: got %v", err)
+	}
+}
+
+func TestPlanFileStaleSource(t *testing.T) {
+	c, mem := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{"permissions": {"allow": ["Read"]}}`,
+	})
+	plan, err := c.Plan(PlanOptions{Rules: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := plan.File()
+	if f.Target.SHA256 != "" {
+		t.Errorf("missing target has a hash: %q", f.Target.SHA256)
+	}
+	if _, err := c.LoadPlan(&f); err != nil {
+		t.Fatal(err)
+	}
+
+	mem.WriteFile(plan.ProjectPath, []byte(`{"permissions": {"allow": ["Read", "Write"]}}`), 0600)
+	_, err = c.LoadPlan(&f)
+	if !errors.Is(err, ErrStalePlan) || !strings.Contains(err.Error(), plan.ProjectPath) {
+		t.Errorf("stale source: got %v", err)
+	}
+}
+
+func TestParsePlanFileErrors(t *testing.T) {
+	for _, in := range []string{
+		`{"format": "other", "version": 1}`,
+		`{"format": "claude-hoist-plan", "version": 2, "source": {"path": "a"}, "target": {"path": "b"}}`,
+		`{"format": "claude-hoist-plan", "version": 1}`,
+		`not json`,
+	} {
+		if _, err := ParsePlanFile([]byte(in)); err == nil {
+			t.Errorf("%s: expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package hoist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// QueueEntry is a candidate rule waiting for review.
+type QueueEntry struct {
+	Rule      string    `json:"rule"`
+	List      List      `json:"list"`
+	Project   string    `json:"project"`            // the project it was first seen in
+	Projects  []string  `json:"projects,omitempty"` // every project it has been seen in
+	FirstSeen time.Time `json:"firstSeen"`          // when it was first queued
+	Count     int       `json:"count"`              // how many projects it has been seen in
+}
+
+// SeenIn reports whether the rule has been seen in project.
+func (e QueueEntry) SeenIn(project string) bool {
+	return e.Project == project || slices.Contains(e.Projects, project)
+}
+
+// Dismissal is a rule the user rejected for good.
+type Dismissal struct {
+	Rule string    `json:"rule"`
+	List List      `json:"list"`
+	At   time.Time `json:"at"`
+}
+
+// Queue holds candidate rules that were noticed but not hoisted, so they
+// can be reviewed later. It is kept in ~/.claude/hoist-queue.json.
+// Dismissed rules are remembered so they are never queued again.
+type Queue struct {
+	Entries   []QueueEntry `json:"entries"`
+	Dismissed []Dismissal  `json:"dismissed,omitempty"`
+}
+
+// QueuePath returns the file the review queue is kept in.
+func (c *Client) QueuePath() string {
+	return filepath.Join(c.opts.Home, ".claude", "hoist-queue.json")
+}
+
+// LoadQueue reads the review queue. A missing file is an empty queue.
+func (c *Client) LoadQueue() (*Queue, error) {
+	var q Queue
+	data, err := c.opts.FS.ReadFile(c.QueuePath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return &q, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", c.QueuePath(), err)
+	}
+	return &q, nil
+}
+
+// UpdateQueue loads the review queue, lets update change it and writes it
+// back, holding the queue's lock throughout.
+func (c *Client) UpdateQueue(update func(q *Queue) error) error {
+	unlock, err := c.Lock(c.QueuePath())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	q, err := c.LoadQueue()
+	if err != nil {
+		return err
+	}
+	if err := update(q); err != nil {
+		return err
+	}
+	if q.Entries == nil {
+		q.Entries = []QueueEntry{}
+	}
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.opts.FS.WriteFile(c.QueuePath(), append(data, '\n'), 0600)
+}
+
+// Find returns the index of the entry for rule in list, or -1.
+func (q *Queue) Find(list List, rule string) int {
+	for i, e := range q.Entries {
+		if e.List == list && e.Rule == rule {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add records that rule was seen in project. A rule already queued is
+// counted once for each project it turns up in, however often it is seen
+// there; a new one is appended. It reports whether the rule is new.
+// Dismissed rules are ignored.
+func (q *Queue) Add(list List, rule, project string, now time.Time) bool {
+	if q.IsDismissed(list, rule) {
+		return false
+	}
+	if i := q.Find(list, rule); i >= 0 {
+		e := &q.Entries[i]
+		if !e.SeenIn(project) {
+			e.Projects = append(e.Projects, project)
+			e.Count++
+		}
+		return false
+	}
+	q.Entries = append(q.Entries, QueueEntry{Rule: rule, List: list, Project: project, Projects: []string{project}, FirstSeen: now.UTC().Truncate(time.Second), Count: 1})
+	return true
+}
+
+// Remove drops the entry for rule in list, if any.
+func (q *Queue) Remove(list List, rule string) {
+	if i := q.Find(list, rule); i >= 0 {
+		q.Entries = append(q.Entries[:i], q.Entries[i+1:]...)
+	}
+}
+
+// IsDismissed reports whether rule in list was dismissed.
+func (q *Queue) IsDismissed(list List, rule string) bool {
+	for _, d := range q.Dismissed {
+		if d.List == list && d.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Dismiss drops the entry for rule in list and keeps it from being queued
+// again.
+func (q *Queue) Dismiss(list List, rule string, now time.Time) {
+	q.Remove(list, rule)
+	if !q.IsDismissed(list, rule) {
+		q.Dismissed = append(q.Dismissed, Dismissal{Rule: rule, List: list, At: now.UTC().Truncate(time.Second)})
+	}
+}
+
+// Prune drops the entries user already has in the same list, such as rules
+// hoisted since they were queued, and returns them.
+func (q *Queue) Prune(user Settings) []QueueEntry {
+	var kept, dropped []QueueEntry
+	for _, e := range q.Entries {
+		if contains(user.Permissions.Rules(e.List), e.Rule) {
+			dropped = append(dropped, e)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	q.Entries = kept
+	return dropped
+}
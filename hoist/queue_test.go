@@ -0,0 +1,98 @@
+package hoist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	c, mem := newTestClient(t, nil)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := c.UpdateQueue(func(q *Queue) error {
+		if !q.Add(ListAllow, "Read", "/work/app", now) || q.Add(ListAllow, "Read", "/work/other", now.Add(time.Hour)) {
+			t.Error("Add: wrong newness")
+		}
+		q.Add(ListAllow, "Read", "/work/app", now.Add(2*time.Hour))
+		q.Add(ListDeny, "Read", "/work/other", now)
+		q.Add(ListAllow, "Bash(ls:*)", "/work/app", now)
+		q.Remove(ListAllow, "Bash(ls:*)")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mem.Files()) != 1 {
+		t.Errorf("files: %v", mem.Files())
+	}
+
+	q, err := c.LoadQueue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []QueueEntry{
+		{Rule: "Read", List: ListAllow, Project: "/work/app", FirstSeen: now, Count: 2},
+		{Rule: "Read", List: ListDeny, Project: "/work/other", FirstSeen: now, Count: 1},
+	}
+	if len(q.Entries) != len(want) {
+		t.Fatalf("got %+v", q.Entries)
+	}
+	for i := range want {
+		if g := q.Entries[i]; g.Rule != want[i].Rule || g.List != want[i].List || g.Project != want[i].Project ||
+			!g.FirstSeen.Equal(want[i].FirstSeen) || g.Count != want[i].Count {
+			t.Errorf("entry %d: got %+v, want %+v", i, g, want[i])
+		}
+	}
+}
+
+func TestQueueDismissAndPrune(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var q Queue
+	q.Add(ListAllow, "Bash(ls:*)", "/work/app", now)
+	q.Add(ListAllow, "Bash(curl:*)", "/work/app", now)
+	q.Add(ListDeny, "Bash(ls:*)", "/work/app", now)
+
+	q.Dismiss(ListAllow, "Bash(curl:*)", now)
+	q.Dismiss(ListAllow, "Bash(curl:*)", now)
+	if len(q.Dismissed) != 1 || q.Find(ListAllow, "Bash(curl:*)") >= 0 {
+		t.Fatalf("dismiss: %+v", q)
+	}
+	if q.Add(ListAllow, "Bash(curl:*)", "/work/other", now) || q.Find(ListAllow, "Bash(curl:*)") >= 0 {
+		t.Error("a dismissed rule was queued again")
+	}
+
+	var user Settings
+	user.Permissions.Allow = []string{"Bash(ls:*)"}
+	dropped := q.Prune(user)
+	if len(dropped) != 1 || dropped[0].List != ListAllow || len(q.Entries) != 1 || q.Entries[0].List != ListDeny {
+		t.Errorf("prune: dropped %+v, kept %+v", dropped, q.Entries)
+	}
+}
+
+func TestPlanSkipDismissed(t *testing.T) {
+	c, _ := newTestClient(t, map[string]string{
+		"/work/app/.claude/settings.local.json": `{"permissions": {"allow": ["Read", "Bash(curl:*)"], "deny": ["Bash(rm:*)"]}}`,
+	})
+	err := c.UpdateQueue(func(q *Queue) error {
+		q.Dismiss(ListAllow, "Bash(curl:*)", time.Now())
+		q.Dismiss(ListDeny, "Bash(rm:*)", time.Now())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := c.Plan(PlanOptions{Rules: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Allow) != 2 || len(plan.Deny) != 1 {
+		t.Errorf("without SkipDismissed: allow %v, deny %v", plan.Allow, plan.Deny)
+	}
+	plan, err = c.Plan(PlanOptions{Rules: true, SkipDismissed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Allow) != 1 || plan.Allow[0] != "Read" || len(plan.Deny) != 0 {
+		t.Errorf("with SkipDismissed: allow %v, deny %v", plan.Allow, plan.Deny)
+	}
+}
@@ -0,0 +1,330 @@
+package hoist
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DiffStyle selects how RenderDiff draws a diff. The zero value gives plain
+// unified output, exactly what UnifiedDiff returns.
+type DiffStyle struct {
+	Color      bool // ANSI colors, with the changed words of modified lines highlighted
+	SideBySide bool // old and new text in two columns
+	Width      int  // total width of side-by-side output; 80 when zero
+}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiCyan    = "\x1b[36m"
+	ansiFaint   = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+)
+
+// RenderDiff returns the differences between a and b, labeled with the given
+// names, drawn in the given style.
+func RenderDiff(aName, bName, a, b string, style DiffStyle) string {
+	if a == b {
+		return ""
+	}
+	hunks := buildHunks(diffLines(splitLines(a), splitLines(b)), 3)
+
+	r := diffRenderer{style: style}
+	if style.SideBySide {
+		r.sideBySide(aName, bName, hunks)
+	} else {
+		r.unified(aName, bName, hunks)
+	}
+	return r.buf.String()
+}
+
+type diffRenderer struct {
+	style DiffStyle
+	buf   strings.Builder
+}
+
+// segment is a piece of a line; changed marks words that differ from the
+// line it is paired with.
+type segment struct {
+	text    string
+	changed bool
+}
+
+// paint writes s wrapped in the given ANSI codes when color is on.
+func (r *diffRenderer) paint(codes, s string) {
+	if !r.style.Color || codes == "" {
+		r.buf.WriteString(s)
+		return
+	}
+	r.buf.WriteString(codes + s + ansiReset)
+}
+
+// segments writes a line's segments in color, highlighting the changed ones.
+func (r *diffRenderer) segments(color string, segs []segment) {
+	for _, s := range segs {
+		if s.changed {
+			r.paint(color+ansiReverse, s.text)
+		} else {
+			r.paint(color, s.text)
+		}
+	}
+}
+
+func (r *diffRenderer) unified(aName, bName string, hunks []hunk) {
+	r.paint(ansiBold, "--- "+aName)
+	r.buf.WriteByte('\n')
+	r.paint(ansiBold, "+++ "+bName)
+	r.buf.WriteByte('\n')
+
+	for _, h := range hunks {
+		r.paint(ansiCyan, fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.aStart, h.aCount), hunkRange(h.bStart, h.bCount)))
+		r.buf.WriteByte('\n')
+		for _, g := range groupOps(h.ops) {
+			if g.context != nil {
+				r.line(' ', "", []segment{{text: g.context.line}}, g.context.noNewline)
+				continue
+			}
+			removed, added := r.pair(g)
+			for i, op := range g.removed {
+				r.line('-', ansiRed, removed[i], op.noNewline)
+			}
+			for i, op := range g.added {
+				r.line('+', ansiGreen, added[i], op.noNewline)
+			}
+		}
+	}
+}
+
+func (r *diffRenderer) line(kind byte, color string, segs []segment, noNewline bool) {
+	r.paint(color, string(kind))
+	r.segments(color, segs)
+	r.buf.WriteByte('\n')
+	if noNewline {
+		r.paint(ansiFaint, "\\ No newline at end of file")
+		r.buf.WriteByte('\n')
+	}
+}
+
+// opGroup is either a single context line or one change: the lines it
+// removes followed by the lines it adds.
+type opGroup struct {
+	context        *diffOp
+	removed, added []diffOp
+}
+
+func groupOps(ops []diffOp) []opGroup {
+	var groups []opGroup
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			groups = append(groups, opGroup{context: &ops[i]})
+			i++
+			continue
+		}
+		var g opGroup
+		for ; i < len(ops) && ops[i].kind == '-'; i++ {
+			g.removed = append(g.removed, ops[i])
+		}
+		for ; i < len(ops) && ops[i].kind == '+'; i++ {
+			g.added = append(g.added, ops[i])
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// pair splits a change's lines into segments. With color on, the n-th removed
+// line is compared word by word with the n-th added line.
+func (r *diffRenderer) pair(g opGroup) (removed, added [][]segment) {
+	for _, op := range g.removed {
+		removed = append(removed, []segment{{text: r.text(op.line)}})
+	}
+	for _, op := range g.added {
+		added = append(added, []segment{{text: r.text(op.line)}})
+	}
+	if !r.style.Color {
+		return removed, added
+	}
+	for i := 0; i < len(removed) && i < len(added); i++ {
+		removed[i], added[i] = wordDiff(removed[i][0].text, added[i][0].text)
+	}
+	return removed, added
+}
+
+// text prepares a line for output: side-by-side columns need tabs expanded.
+func (r *diffRenderer) text(line string) string {
+	if !r.style.SideBySide || !strings.Contains(line, "\t") {
+		return line
+	}
+	var b strings.Builder
+	col := 0
+	for _, c := range line {
+		if c == '\t' {
+			n := 8 - col%8
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(c)
+		col++
+	}
+	return b.String()
+}
+
+// wordDiff splits old and new into words and marks the ones that differ.
+// Lines with no word in common, only punctuation, are left unmarked since
+// they aren't edits of each other.
+func wordDiff(old, new string) (a, b []segment) {
+	ta, tb := splitWords(old), splitWords(new)
+	delA, insB := changedLines(ta, tb)
+
+	common := false
+	for i, t := range ta {
+		if !delA[i] && isWordByte(t[0]) {
+			common = true
+			break
+		}
+	}
+	if !common {
+		return []segment{{text: old}}, []segment{{text: new}}
+	}
+	return joinSegments(ta, delA), joinSegments(tb, insB)
+}
+
+// splitWords splits s into runs of letters and digits, runs of spaces, and
+// single punctuation characters.
+func splitWords(s string) []string {
+	var words []string
+	for i := 0; i < len(s); {
+		j := i + 1
+		switch c := s[i]; {
+		case isWordByte(c):
+			for j < len(s) && isWordByte(s[j]) {
+				j++
+			}
+		case c == ' ' || c == '\t':
+			for j < len(s) && (s[j] == ' ' || s[j] == '\t') {
+				j++
+			}
+		default:
+			_, size := utf8.DecodeRuneInString(s[i:])
+			j = i + size
+		}
+		words = append(words, s[i:j])
+		i = j
+	}
+	return words
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c >= utf8.RuneSelf
+}
+
+func joinSegments(words []string, changed []bool) []segment {
+	var segs []segment
+	for i, w := range words {
+		if n := len(segs); n > 0 && segs[n-1].changed == changed[i] {
+			segs[n-1].text += w
+			continue
+		}
+		segs = append(segs, segment{text: w, changed: changed[i]})
+	}
+	return segs
+}
+
+func (r *diffRenderer) sideBySide(aName, bName string, hunks []hunk) {
+	width := r.style.Width
+	if width <= 0 {
+		width = 80
+	}
+	col := max(10, (width-3)/2)
+
+	r.row(col, ansiBold, []segment{{text: aName}}, ' ', ansiBold, []segment{{text: bName}})
+	for _, h := range hunks {
+		r.paint(ansiCyan, fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.aStart, h.aCount), hunkRange(h.bStart, h.bCount)))
+		r.buf.WriteByte('\n')
+		for _, g := range groupOps(h.ops) {
+			if g.context != nil {
+				segs := []segment{{text: r.text(g.context.line)}}
+				r.row(col, "", segs, ' ', "", segs)
+				r.noNewlineRow(col, g.context.noNewline, g.context.noNewline)
+				continue
+			}
+			removed, added := r.pair(g)
+			for i := 0; i < max(len(removed), len(added)); i++ {
+				switch {
+				case i >= len(added):
+					r.row(col, ansiRed, removed[i], '<', "", nil)
+				case i >= len(removed):
+					r.row(col, "", nil, '>', ansiGreen, added[i])
+				default:
+					r.row(col, ansiRed, removed[i], '|', ansiGreen, added[i])
+				}
+				r.noNewlineRow(col, i < len(g.removed) && g.removed[i].noNewline, i < len(g.added) && g.added[i].noNewline)
+			}
+		}
+	}
+}
+
+// row writes one side-by-side line: left and right fitted to col characters
+// with a gutter marker between them, like diff -y.
+func (r *diffRenderer) row(col int, lcolor string, left []segment, gutter byte, rcolor string, right []segment) {
+	left, n := fitSegments(left, col)
+	r.segments(lcolor, left)
+	r.buf.WriteString(strings.Repeat(" ", col-n))
+	r.buf.WriteString(" " + string(gutter) + " ")
+	right, _ = fitSegments(right, col)
+	r.segments(rcolor, right)
+	r.buf.WriteByte('\n')
+}
+
+// noNewlineRow writes the "\ No newline at end of file" marker under the
+// sides whose line lacks one, if any.
+func (r *diffRenderer) noNewlineRow(col int, left, right bool) {
+	if !left && !right {
+		return
+	}
+	marker := []segment{{text: "\\ No newline at end of file"}}
+	var l, rt []segment
+	if left {
+		l = marker
+	}
+	if right {
+		rt = marker
+	}
+	r.row(col, ansiFaint, l, ' ', ansiFaint, rt)
+}
+
+// fitSegments truncates segs to at most width characters, marking a cut with
+// an ellipsis, and returns them with their length.
+func fitSegments(segs []segment, width int) ([]segment, int) {
+	n := 0
+	for _, s := range segs {
+		n += utf8.RuneCountInString(s.text)
+	}
+	if n <= width {
+		return segs, n
+	}
+	if width <= 0 {
+		return nil, 0
+	}
+
+	var out []segment
+	keep := width - 1 // room for the ellipsis
+	for _, s := range segs {
+		if keep == 0 {
+			break
+		}
+		runes := []rune(s.text)
+		if len(runes) > keep {
+			runes = runes[:keep]
+		}
+		out = append(out, segment{text: string(runes), changed: s.changed})
+		keep -= len(runes)
+	}
+	out = append(out, segment{text: "…"})
+	return out, width
+}
@@ -0,0 +1,86 @@
+package hoist
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWordDiff(t *testing.T) {
+	a, b := wordDiff(`    "Bash(npm run test)",`, `    "Bash(npm run test:*)",`)
+	wantA := []segment{{text: `    "Bash(npm run test)",`}}
+	wantB := []segment{{text: `    "Bash(npm run test`}, {text: ":*", changed: true}, {text: `)",`}}
+	if !reflect.DeepEqual(a, wantA) || !reflect.DeepEqual(b, wantB) {
+		t.Fatalf("got %v / %v", a, b)
+	}
+
+	// Lines with only punctuation in common aren't highlighted.
+	a, b = wordDiff(`"deny": []`, `"Bash(ls)",`)
+	if len(a) != 1 || len(b) != 1 || a[0].changed || b[0].changed {
+		t.Fatalf("expected no highlighting, got %v / %v", a, b)
+	}
+}
+
+func TestRenderDiffColor(t *testing.T) {
+	got := RenderDiff("a", "b", "x\nBash(npm run test)\n", "x\nBash(npm run test:*)\n", DiffStyle{Color: true})
+	want := ansiBold + "--- a" + ansiReset + "\n" +
+		ansiBold + "+++ b" + ansiReset + "\n" +
+		ansiCyan + "@@ -1,2 +1,2 @@" + ansiReset + "\n" +
+		" x\n" +
+		ansiRed + "-" + ansiReset + ansiRed + "Bash(npm run test)" + ansiReset + "\n" +
+		ansiGreen + "+" + ansiReset + ansiGreen + "Bash(npm run test" + ansiReset +
+		ansiGreen + ansiReverse + ":*" + ansiReset + ansiGreen + ")" + ansiReset + "\n"
+	if got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestRenderDiffSideBySide(t *testing.T) {
+	a := "keep\nold\ngone\n"
+	b := "keep\nnew\n\tadded and much too long for the column\n"
+	got := RenderDiff("a", "b", a, b, DiffStyle{SideBySide: true, Width: 33})
+	want := strings.Join([]string{
+		"a                 b",
+		"@@ -1,3 +1,3 @@",
+		"keep              keep",
+		"old             | new",
+		"gone            |         added …",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderDiffSideBySideNoNewline(t *testing.T) {
+	got := RenderDiff("a", "b", "keep\nold", "keep\nnew\n", DiffStyle{SideBySide: true, Width: 33})
+	want := strings.Join([]string{
+		"a                 b",
+		"@@ -1,2 +1,2 @@",
+		"keep              keep",
+		"old             | new",
+		"\\ No newline a…   ",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFitSegments(t *testing.T) {
+	segs := []segment{{text: "abcde"}, {text: "fgh", changed: true}}
+	for width := 0; width <= 9; width++ {
+		out, n := fitSegments(segs, width)
+		text := ""
+		for _, s := range out {
+			text += s.text
+		}
+		if l := utf8.RuneCountInString(text); l != n || n > width {
+			t.Errorf("width %d: got %q with length %d", width, text, n)
+		}
+	}
+	if out, _ := fitSegments(segs, 6); len(out) != 2 || out[0].text != "abcde" || out[1].text != "…" {
+		t.Errorf("cut at a segment boundary: %+v", out)
+	}
+}
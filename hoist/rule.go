@@ -0,0 +1,324 @@
+package hoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Rule is a parsed permission rule such as "Bash(npm run test:*)" or "WebSearch".
+type Rule struct {
+	Tool      string
+	Specifier string // text between the parentheses, empty when the rule covers the whole tool
+}
+
+// BuiltinTools lists the Claude Code tools permission rules commonly name.
+var BuiltinTools = []string{"Bash", "Edit", "Glob", "Grep", "MultiEdit", "NotebookEdit", "Read", "Task", "TodoWrite", "WebFetch", "WebSearch", "Write"}
+
+// ParseRule parses a rule string of the form Tool or Tool(specifier).
+func ParseRule(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Rule{}, fmt.Errorf("empty rule")
+	}
+
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		if strings.Contains(s, ")") {
+			return Rule{}, fmt.Errorf("rule %q: unbalanced parenthesis", s)
+		}
+		if !validToolName(s) {
+			return Rule{}, fmt.Errorf("rule %q: invalid tool name", s)
+		}
+		return Rule{Tool: s}, nil
+	}
+
+	if !strings.HasSuffix(s, ")") {
+		return Rule{}, fmt.Errorf("rule %q: missing closing parenthesis", s)
+	}
+	tool := s[:open]
+	if !validToolName(tool) {
+		return Rule{}, fmt.Errorf("rule %q: invalid tool name", s)
+	}
+	spec := s[open+1 : len(s)-1]
+	if spec == "" {
+		return Rule{}, fmt.Errorf("rule %q: empty specifier", s)
+	}
+	return Rule{Tool: tool, Specifier: spec}, nil
+}
+
+func validToolName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '*') {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) String() string {
+	if r.Specifier == "" {
+		return r.Tool
+	}
+	return r.Tool + "(" + r.Specifier + ")"
+}
+
+// ToolCall is a single tool invocation to evaluate against rules.
+// Input holds the argument rules are matched against: the command for Bash,
+// the file path for file tools, the URL for WebFetch.
+type ToolCall struct {
+	Tool  string
+	Input string
+	Cwd   string // directory relative paths are resolved against
+	Home  string // directory ~ expands to
+}
+
+func (c ToolCall) String() string {
+	if c.Input == "" {
+		return c.Tool
+	}
+	return c.Tool + "(" + c.Input + ")"
+}
+
+// ParseToolCall parses a call written in rule syntax, e.g. "Bash(git push origin main)".
+func ParseToolCall(s string) (ToolCall, error) {
+	r, err := ParseRule(s)
+	if err != nil {
+		return ToolCall{}, err
+	}
+	return ToolCall{Tool: r.Tool, Input: r.Specifier}, nil
+}
+
+// ParseToolCallJSON parses a hook-style payload:
+//
+//	{"tool_name": "Bash", "tool_input": {"command": "git push"}, "cwd": "/repo"}
+func ParseToolCallJSON(data []byte) (ToolCall, error) {
+	var payload struct {
+		ToolName  string         `json:"tool_name"`
+		ToolInput map[string]any `json:"tool_input"`
+		Cwd       string         `json:"cwd"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return ToolCall{}, fmt.Errorf("parsing tool call: %w", err)
+	}
+	if payload.ToolName == "" {
+		return ToolCall{}, fmt.Errorf("tool call has no tool_name")
+	}
+
+	call := ToolCall{Tool: payload.ToolName, Cwd: payload.Cwd}
+	for _, key := range []string{"command", "file_path", "notebook_path", "path", "url", "pattern"} {
+		if v, ok := payload.ToolInput[key].(string); ok && v != "" {
+			call.Input = v
+			break
+		}
+	}
+	return call, nil
+}
+
+// Matches reports whether the rule covers the given call.
+func (r Rule) Matches(call ToolCall) bool {
+	if !r.matchesTool(call.Tool) {
+		return false
+	}
+	if r.Specifier == "" || r.Specifier == "*" {
+		return true
+	}
+	if call.Input == "" {
+		return false
+	}
+
+	switch r.Tool {
+	case "Bash":
+		return matchCommand(r.Specifier, call.Input)
+	case "Read", "Edit", "Write", "MultiEdit", "NotebookEdit", "Glob", "Grep":
+		return matchPath(r.Specifier, call)
+	case "WebFetch":
+		return matchDomain(r.Specifier, call.Input)
+	default:
+		return globMatch(r.Specifier, call.Input, false)
+	}
+}
+
+// Covers reports whether every call matched by o is also matched by r.
+// It is conservative: false means the coverage could not be established.
+func (r Rule) Covers(o Rule) bool {
+	if server, ok := mcpServerRule(o.Tool); ok {
+		rServer, rok := mcpServerRule(r.Tool)
+		return rok && rServer == server
+	}
+	if !r.matchesTool(o.Tool) {
+		return false
+	}
+	if r.Specifier == "" || r.Specifier == "*" {
+		return true
+	}
+	if o.Specifier == "" || r.Tool != o.Tool {
+		return false
+	}
+	if r.Specifier == o.Specifier {
+		return true
+	}
+
+	// The literal part of o's specifier before any wildcard.
+	literal, _, wild := strings.Cut(strings.TrimSuffix(o.Specifier, ":*"), "*")
+	wild = wild || strings.HasSuffix(o.Specifier, ":*")
+
+	if prefix, ok := strings.CutSuffix(r.Specifier, ":*"); ok && r.Tool == "Bash" {
+		return strings.HasPrefix(literal, prefix)
+	}
+	if strings.Contains(r.Specifier, "*") && !wild {
+		switch r.Tool {
+		case "Read", "Edit", "Write", "MultiEdit", "NotebookEdit", "Glob", "Grep":
+			return globMatch(r.Specifier, o.Specifier, true)
+		}
+		return globMatch(r.Specifier, o.Specifier, false)
+	}
+	return false
+}
+
+func (r Rule) matchesTool(tool string) bool {
+	if r.Tool == tool {
+		return true
+	}
+	if server, ok := mcpServerRule(r.Tool); ok {
+		return strings.HasPrefix(tool, "mcp__"+server+"__")
+	}
+	if strings.HasPrefix(r.Tool, "mcp__") {
+		return globMatch(r.Tool, tool, false)
+	}
+	return false
+}
+
+// mcpServerRule reports whether tool is mcp__server or mcp__server__*,
+// which cover every tool the server exposes, and returns the server name.
+func mcpServerRule(tool string) (string, bool) {
+	rest, ok := strings.CutPrefix(tool, "mcp__")
+	if !ok {
+		return "", false
+	}
+	rest = strings.TrimSuffix(rest, "__*")
+	if rest == "" || strings.Contains(rest, "__") {
+		return "", false
+	}
+	return rest, true
+}
+
+// matchCommand implements Bash specifiers: "npm run test:*" is a prefix match
+// on whole words, any other "*" is a wildcard, everything else must match
+// exactly. Prefixes and wildcards never match a command chained with a shell
+// operator, so "git:*" doesn't cover "git status && rm -rf ~".
+func matchCommand(spec, command string) bool {
+	command = strings.TrimSpace(command)
+	if prefix, ok := strings.CutSuffix(spec, ":*"); ok {
+		if chained(command) {
+			return false
+		}
+		rest, ok := strings.CutPrefix(command, prefix)
+		return ok && (rest == "" || rest[0] == ' ' || strings.HasSuffix(prefix, " "))
+	}
+	if strings.Contains(spec, "*") {
+		return !chained(command) && globMatch(spec, command, false)
+	}
+	return spec == command
+}
+
+// chained reports whether command contains a shell operator that runs or
+// feeds another command: "&&", "||", ";", "|" or a newline.
+func chained(command string) bool {
+	return strings.ContainsAny(command, ";|\n") || strings.Contains(command, "&&")
+}
+
+// matchPath implements gitignore-style path specifiers for file tools:
+//
+//	//abs/path   absolute path
+//	~/path       relative to the home directory
+//	/path, path  relative to the working directory
+//
+// A pattern without a slash matches a file name at any depth.
+func matchPath(spec string, call ToolCall) bool {
+	target := call.Input
+	if strings.HasPrefix(target, "~/") && call.Home != "" {
+		target = filepath.Join(call.Home, target[2:])
+	}
+	if !filepath.IsAbs(target) && call.Cwd != "" {
+		target = filepath.Join(call.Cwd, target)
+	}
+	target = filepath.ToSlash(filepath.Clean(target))
+
+	var pattern string
+	switch {
+	case strings.HasPrefix(spec, "//"):
+		pattern = spec[1:]
+	case strings.HasPrefix(spec, "~/"):
+		if call.Home == "" {
+			return false
+		}
+		pattern = path.Join(filepath.ToSlash(call.Home), spec[2:])
+	case !strings.Contains(strings.TrimSuffix(spec, "/"), "/"):
+		return globMatch(spec, path.Base(target), true) || globMatch("**/"+spec, target, true)
+	default:
+		base := filepath.ToSlash(call.Cwd)
+		if base == "" {
+			base = "/"
+		}
+		pattern = path.Join(base, strings.TrimPrefix(spec, "./"))
+	}
+	if strings.HasSuffix(spec, "/") {
+		pattern += "/**"
+	}
+	return globMatch(pattern, target, true)
+}
+
+func matchDomain(spec, rawURL string) bool {
+	domain, ok := strings.CutPrefix(spec, "domain:")
+	if !ok {
+		return globMatch(spec, rawURL, false)
+	}
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	return globMatch(strings.ToLower(domain), strings.ToLower(host), false)
+}
+
+// globMatch matches s against a pattern where "*" matches any run of
+// characters. When paths is set, "*" stops at "/" and "**" crosses it.
+func globMatch(pattern, s string, paths bool) bool {
+	if pattern == "" {
+		return s == ""
+	}
+
+	if strings.HasPrefix(pattern, "**") && paths {
+		rest := strings.TrimPrefix(pattern[2:], "/")
+		for i := 0; i <= len(s); i++ {
+			if (i == 0 || s[i-1] == '/') && globMatch(rest, s[i:], paths) {
+				return true
+			}
+		}
+		return rest == "" || globMatch(rest, s, paths)
+	}
+
+	if pattern[0] == '*' {
+		rest := pattern[1:]
+		for i := 0; i <= len(s); i++ {
+			if globMatch(rest, s[i:], paths) {
+				return true
+			}
+			if i < len(s) && paths && s[i] == '/' {
+				return false
+			}
+		}
+		return false
+	}
+
+	if s == "" || pattern[0] != s[0] {
+		return false
+	}
+	return globMatch(pattern[1:], s[1:], paths)
+}
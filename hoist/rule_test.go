@@ -0,0 +1,153 @@
+package hoist
+
+import "testing"
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		input   string
+		tool    string
+		spec    string
+		wantErr bool
+	}{
+		{"WebSearch", "WebSearch", "", false},
+		{"Bash(npm run test:*)", "Bash", "npm run test:*", false},
+		{"Bash(echo (nested))", "Bash", "echo (nested)", false},
+		{"mcp__github__create_issue", "mcp__github__create_issue", "", false},
+		{"", "", "", true},
+		{"Bash(", "", "", true},
+		{"Bash()", "", "", true},
+		{"Bash)", "", "", true},
+		{"Bad Tool", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRule(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRule(%q): expected error, got %+v", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRule(%q): %v", tt.input, err)
+			continue
+		}
+		if got.Tool != tt.tool || got.Specifier != tt.spec {
+			t.Errorf("ParseRule(%q) = %+v, want tool %q spec %q", tt.input, got, tt.tool, tt.spec)
+		}
+		if got.String() != tt.input {
+			t.Errorf("String() = %q, want %q", got.String(), tt.input)
+		}
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		rule string
+		call ToolCall
+		want bool
+	}{
+		{"Bash", ToolCall{Tool: "Bash", Input: "rm -rf /"}, true},
+		{"Bash(git push:*)", ToolCall{Tool: "Bash", Input: "git push origin main"}, true},
+		{"Bash(git push:*)", ToolCall{Tool: "Bash", Input: "git pull"}, false},
+		{"Bash(git push:*)", ToolCall{Tool: "Bash", Input: "git push"}, true},
+		{"Bash(git push:*)", ToolCall{Tool: "Bash", Input: "git pushx origin"}, false},
+		{"Bash(ls:*)", ToolCall{Tool: "Bash", Input: "lsblk"}, false},
+		{"Bash(git:*)", ToolCall{Tool: "Bash", Input: "git status && rm -rf ~"}, false},
+		{"Bash(git:*)", ToolCall{Tool: "Bash", Input: "git status || rm -rf ~"}, false},
+		{"Bash(git:*)", ToolCall{Tool: "Bash", Input: "git status; rm -rf ~"}, false},
+		{"Bash(git:*)", ToolCall{Tool: "Bash", Input: "git log | sh"}, false},
+		{"Bash(git * main)", ToolCall{Tool: "Bash", Input: "git push; rm -rf ~; echo main"}, false},
+		{"Bash(npm run test)", ToolCall{Tool: "Bash", Input: "npm run test"}, true},
+		{"Bash(npm run test)", ToolCall{Tool: "Bash", Input: "npm run test --watch"}, false},
+		{"Bash(git * main)", ToolCall{Tool: "Bash", Input: "git push origin main"}, true},
+		{"Bash(ls:*)", ToolCall{Tool: "Read", Input: "ls"}, false},
+		{"Read(./src/**)", ToolCall{Tool: "Read", Input: "src/a/b.go", Cwd: "/repo"}, true},
+		{"Read(./src/**)", ToolCall{Tool: "Read", Input: "/repo/docs/a.md", Cwd: "/repo"}, false},
+		{"Read(*.env)", ToolCall{Tool: "Read", Input: "/repo/config/.prod.env", Cwd: "/repo"}, true},
+		{"Read(//etc/**)", ToolCall{Tool: "Read", Input: "/etc/passwd", Cwd: "/repo"}, true},
+		{"Edit(~/notes/*)", ToolCall{Tool: "Edit", Input: "/home/me/notes/todo.md", Home: "/home/me"}, true},
+		{"Edit(~/notes/*)", ToolCall{Tool: "Edit", Input: "/home/me/notes/sub/todo.md", Home: "/home/me"}, false},
+		{"WebFetch(domain:github.com)", ToolCall{Tool: "WebFetch", Input: "https://github.com/x/y"}, true},
+		{"WebFetch(domain:github.com)", ToolCall{Tool: "WebFetch", Input: "https://gitlab.com/x/y"}, false},
+		{"mcp__github", ToolCall{Tool: "mcp__github__create_issue"}, true},
+		{"mcp__github__*", ToolCall{Tool: "mcp__github__create_issue"}, true},
+		{"mcp__github__create_issue", ToolCall{Tool: "mcp__github__list_issues"}, false},
+		{"mcp__github", ToolCall{Tool: "mcp__gitlab__create_issue"}, false},
+	}
+	for _, tt := range tests {
+		r, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Fatalf("ParseRule(%q): %v", tt.rule, err)
+		}
+		if got := r.Matches(tt.call); got != tt.want {
+			t.Errorf("%s matches %s = %v, want %v", tt.rule, tt.call, got, tt.want)
+		}
+	}
+}
+
+func TestParseToolCallJSON(t *testing.T) {
+	call, err := ParseToolCallJSON([]byte(`{"tool_name":"Bash","tool_input":{"command":"git status"},"cwd":"/repo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Tool != "Bash" || call.Input != "git status" || call.Cwd != "/repo" {
+		t.Fatalf("got %+v", call)
+	}
+
+	if _, err := ParseToolCallJSON([]byte(`{"tool_input":{}}`)); err == nil {
+		t.Fatal("expected error for missing tool_name")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	scopes := []ScopeSettings{
+		{Scope: ScopeProjectLocal, Path: "p", Settings: Settings{Permissions: Permissions{
+			Allow: []string{"Bash(git:*)"},
+		}}},
+		{Scope: ScopeUserLocal, Path: "u", Settings: Settings{Permissions: Permissions{
+			Allow: []string{"Bash(git push:*)"},
+			Deny:  []string{"Bash(git push:*)"},
+		}}},
+	}
+
+	got := Explain(ToolCall{Tool: "Bash", Input: "git push origin main"}, scopes)
+	if len(got) != 3 {
+		t.Fatalf("got %d matches, want 3: %+v", len(got), got)
+	}
+	if got[0].List != ListDeny || got[0].Scope != ScopeUserLocal {
+		t.Fatalf("winner = %+v, want user deny", got[0])
+	}
+	if got[1].Scope != ScopeProjectLocal || got[2].Scope != ScopeUserLocal {
+		t.Fatalf("allow matches out of precedence order: %+v", got[1:])
+	}
+
+	if got := Explain(ToolCall{Tool: "Read", Input: "x"}, scopes); len(got) != 0 {
+		t.Fatalf("expected no matches, got %+v", got)
+	}
+}
+
+func TestRuleCovers(t *testing.T) {
+	tests := []struct {
+		r, o string
+		want bool
+	}{
+		{"Bash", "Bash(ls:*)", true},
+		{"Bash(git:*)", "Bash(git push:*)", true},
+		{"Bash(git:*)", "Bash(git push origin main)", true},
+		{"Bash(git push:*)", "Bash(git:*)", false},
+		{"Bash(git * main)", "Bash(git push origin main)", true},
+		{"Bash(ls)", "Bash(ls)", true},
+		{"Bash(ls)", "Read(ls)", false},
+		{"Read(./src/**)", "Read(./src/a/b.go)", true},
+		{"mcp__github", "mcp__github__create_issue", true},
+		{"mcp__github__*", "mcp__github", true},
+		{"mcp__github__create_issue", "mcp__github", false},
+	}
+	for _, tt := range tests {
+		r, _ := ParseRule(tt.r)
+		o, _ := ParseRule(tt.o)
+		if got := r.Covers(o); got != tt.want {
+			t.Errorf("%s covers %s = %v, want %v", tt.r, tt.o, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package hoist
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Scope identifies one of the settings files Claude Code reads.
+// Scopes are declared in precedence order: earlier scopes win.
+type Scope int
+
+const (
+	ScopeManaged Scope = iota
+	ScopeProjectLocal
+	ScopeProjectShared
+	ScopeUserLocal
+	ScopeUserShared
+)
+
+// Scopes lists every scope in precedence order.
+var Scopes = []Scope{ScopeManaged, ScopeProjectLocal, ScopeProjectShared, ScopeUserLocal, ScopeUserShared}
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeManaged:
+		return "managed"
+	case ScopeProjectLocal:
+		return "project"
+	case ScopeProjectShared:
+		return "project-shared"
+	case ScopeUserLocal:
+		return "user"
+	case ScopeUserShared:
+		return "user-shared"
+	}
+	return fmt.Sprintf("scope(%d)", int(s))
+}
+
+func (s Scope) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ManagedSettingsPath returns the system-wide managed (enterprise) settings
+// file. CLAUDE_HOIST_MANAGED_SETTINGS overrides the location.
+func ManagedSettingsPath() string {
+	if p := os.Getenv("CLAUDE_HOIST_MANAGED_SETTINGS"); p != "" {
+		return p
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return "/Library/Application Support/ClaudeCode/managed-settings.json"
+	case "windows":
+		return `C:\ProgramData\ClaudeCode\managed-settings.json`
+	}
+	return "/etc/claude-code/managed-settings.json"
+}
+
+// ClaudeJSONPath returns ~/.claude.json, where Claude Code keeps its
+// per-user state and MCP server configuration.
+func ClaudeJSONPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude.json"), nil
+}
+
+// ScopePath returns the settings file for a scope. Project scopes are
+// resolved against projectDir.
+func ScopePath(scope Scope, projectDir string) (string, error) {
+	home := ""
+	if scope == ScopeUserLocal || scope == ScopeUserShared {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return "", err
+		}
+	}
+	return scopePath(scope, projectDir, home, ManagedSettingsPath())
+}
+
+func scopePath(scope Scope, projectDir, home, managed string) (string, error) {
+	switch scope {
+	case ScopeManaged:
+		return managed, nil
+	case ScopeProjectLocal:
+		return filepath.Join(projectDir, ".claude", "settings.local.json"), nil
+	case ScopeProjectShared:
+		return filepath.Join(projectDir, ".claude", "settings.json"), nil
+	case ScopeUserLocal:
+		return filepath.Join(home, ".claude", "settings.local.json"), nil
+	case ScopeUserShared:
+		return filepath.Join(home, ".claude", "settings.json"), nil
+	}
+	return "", fmt.Errorf("unknown scope %v", scope)
+}
+
+// ScopeSettings is the parsed content of one scope's settings file.
+type ScopeSettings struct {
+	Scope    Scope
+	Path     string
+	Settings Settings
+}
+
+// LoadScopes reads every scope's settings file in precedence order.
+// Files that don't exist are skipped.
+func LoadScopes(projectDir string) ([]ScopeSettings, error) {
+	c, err := New(Options{ProjectDir: projectDir})
+	if err != nil {
+		return nil, err
+	}
+	return c.LoadScopes()
+}
+
+// LoadManaged reads the managed settings file. Managed settings are read-only
+// to claude-hoist; a missing file yields empty settings.
+func LoadManaged() (Settings, string, error) {
+	c := &Client{opts: Options{FS: OSFS{}, ManagedPath: ManagedSettingsPath()}}
+	return c.LoadManaged()
+}
+
+// Blocked splits allow candidates into rules that can take effect and rules a
+// managed deny rule forbids. Blocked rules map to the managed rule responsible.
+func Blocked(candidates []string, managed Settings) (ok []string, blocked map[string]string) {
+	blocked = make(map[string]string)
+	for _, raw := range candidates {
+		if by := blockingRule(raw, managed.Permissions.Deny); by != "" {
+			blocked[raw] = by
+			continue
+		}
+		ok = append(ok, raw)
+	}
+	return ok, blocked
+}
+
+func blockingRule(raw string, deny []string) string {
+	rule, err := ParseRule(raw)
+	if err != nil {
+		return ""
+	}
+	for _, d := range deny {
+		dr, err := ParseRule(d)
+		if err == nil && dr.Covers(rule) {
+			return d
+		}
+	}
+	return ""
+}
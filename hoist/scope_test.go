@@ -0,0 +1,62 @@
+package hoist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManaged(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "managed-settings.json")
+	t.Setenv("CLAUDE_HOIST_MANAGED_SETTINGS", path)
+
+	s, p, err := LoadManaged()
+	if err != nil {
+		t.Fatalf("missing managed file should not be an error: %v", err)
+	}
+	if p != path || len(s.Permissions.Deny) != 0 {
+		t.Fatalf("got %q %+v", p, s)
+	}
+
+	os.WriteFile(path, []byte(`{"permissions":{"deny":["Bash(curl:*)"]}}`), 0644)
+	s, _, err = LoadManaged()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Permissions.Deny) != 1 {
+		t.Fatalf("deny: got %v", s.Permissions.Deny)
+	}
+}
+
+func TestBlocked(t *testing.T) {
+	managed := Settings{Permissions: Permissions{Deny: []string{"Bash(curl:*)", "WebFetch"}}}
+
+	ok, blocked := Blocked([]string{"Bash(ls:*)", "Bash(curl -s example.com)", "WebFetch(domain:github.com)"}, managed)
+
+	if len(ok) != 1 || ok[0] != "Bash(ls:*)" {
+		t.Fatalf("ok: got %v", ok)
+	}
+	if blocked["Bash(curl -s example.com)"] != "Bash(curl:*)" {
+		t.Fatalf("blocked: got %v", blocked)
+	}
+	if blocked["WebFetch(domain:github.com)"] != "WebFetch" {
+		t.Fatalf("blocked: got %v", blocked)
+	}
+}
+
+func TestLoadScopesSkipsMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CLAUDE_HOIST_MANAGED_SETTINGS", filepath.Join(t.TempDir(), "none.json"))
+	project := t.TempDir()
+	os.MkdirAll(filepath.Join(project, ".claude"), 0755)
+	os.WriteFile(filepath.Join(project, ".claude", "settings.json"), []byte(`{"permissions":{"allow":["Read"]}}`), 0644)
+
+	scopes, err := LoadScopes(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scopes) != 1 || scopes[0].Scope != ScopeProjectShared {
+		t.Fatalf("got %+v", scopes)
+	}
+}
@@ -0,0 +1,305 @@
+package hoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind says what happened to a permission rule between two versions
+// of a settings file.
+type ChangeKind string
+
+const (
+	ChangeAdded     ChangeKind = "added"
+	ChangeRemoved   ChangeKind = "removed"
+	ChangeMoved     ChangeKind = "moved"     // the rule left one list for another
+	ChangeRedundant ChangeKind = "redundant" // the rule stayed but another rule now covers it
+)
+
+// changeKinds lists the kinds in the order they are reported.
+var changeKinds = []ChangeKind{ChangeAdded, ChangeRemoved, ChangeMoved, ChangeRedundant}
+
+// RuleChange is one change to one permission rule.
+type RuleChange struct {
+	Kind ChangeKind `json:"kind"`
+	Rule string     `json:"rule"`
+	Tool string     `json:"tool"`           // the tool, or mcp__server for MCP tools
+	From List       `json:"from,omitempty"` // the list it was in, unless added
+	To   List       `json:"to,omitempty"`   // the list it is in, unless removed
+	By   string     `json:"by,omitempty"`   // the rule that makes it redundant
+}
+
+// ToolChanges are the rule changes for one tool.
+type ToolChanges struct {
+	Tool    string       `json:"tool"`
+	Changes []RuleChange `json:"changes"`
+}
+
+// SemanticDiff is the difference between two versions of a settings file in
+// terms of permission rules and single-valued settings rather than text.
+type SemanticDiff struct {
+	Tools   []ToolChanges  `json:"tools"`   // sorted by tool
+	Scalars []ScalarChange `json:"scalars"` // sorted by key
+}
+
+// Empty reports whether nothing changed.
+func (d SemanticDiff) Empty() bool {
+	return len(d.Tools) == 0 && len(d.Scalars) == 0
+}
+
+// Counts returns the number of rule changes of each kind.
+func (d SemanticDiff) Counts() map[ChangeKind]int {
+	counts := make(map[ChangeKind]int)
+	for _, t := range d.Tools {
+		for _, c := range t.Changes {
+			counts[c.Kind]++
+		}
+	}
+	return counts
+}
+
+// DiffSemantic compares the permission rules and single-valued settings of
+// before and after. The result doesn't depend on formatting or on the order
+// of rules within a list.
+func DiffSemantic(before, after Settings) SemanticDiff {
+	type entry struct {
+		list List
+		rule string
+	}
+	has := func(s Settings) map[entry]bool {
+		m := make(map[entry]bool)
+		for _, l := range Lists {
+			for _, r := range s.Permissions.Rules(l) {
+				m[entry{l, r}] = true
+			}
+		}
+		return m
+	}
+	had, have := has(before), has(after)
+
+	var changes []RuleChange
+	var removed []entry
+	for _, l := range Lists {
+		for _, r := range dedup(before.Permissions.Rules(l)) {
+			if !have[entry{l, r}] {
+				removed = append(removed, entry{l, r})
+			}
+		}
+	}
+	movedFrom := make(map[string]List)
+	for _, e := range removed {
+		movedFrom[e.rule] = e.list
+	}
+	moved := make(map[entry]bool)
+	for _, l := range Lists {
+		for _, r := range dedup(after.Permissions.Rules(l)) {
+			if had[entry{l, r}] {
+				continue
+			}
+			if from, ok := movedFrom[r]; ok {
+				changes = append(changes, RuleChange{Kind: ChangeMoved, Rule: r, From: from, To: l})
+				moved[entry{from, r}] = true
+				delete(movedFrom, r)
+				continue
+			}
+			changes = append(changes, RuleChange{Kind: ChangeAdded, Rule: r, To: l})
+		}
+	}
+	for _, e := range removed {
+		if !moved[e] {
+			changes = append(changes, RuleChange{Kind: ChangeRemoved, Rule: e.rule, From: e.list})
+		}
+	}
+
+	// Rules kept in place that something else now covers.
+	status := func(s Settings) map[entry]EffectiveRule {
+		m := make(map[entry]EffectiveRule)
+		for _, r := range Effective([]ScopeSettings{{Scope: ScopeUserLocal, Settings: s}}) {
+			if _, seen := m[entry{r.List, r.Rule}]; !seen {
+				m[entry{r.List, r.Rule}] = r
+			}
+		}
+		return m
+	}
+	was, is := status(before), status(after)
+	scope := " [" + ScopeUserLocal.String() + "]"
+	for e, r := range is {
+		old, ok := was[e]
+		if !ok || old.Status != StatusEffective || (r.Status != StatusShadowed && r.Status != StatusOverridden) {
+			continue
+		}
+		changes = append(changes, RuleChange{Kind: ChangeRedundant, Rule: e.rule, From: e.list, To: e.list, By: strings.TrimSuffix(r.By, scope)})
+	}
+
+	return SemanticDiff{Tools: groupByTool(changes), Scalars: diffScalars(before, after)}
+}
+
+// ruleTool returns the tool a rule is grouped under: its tool name, or
+// mcp__server for MCP tools.
+func ruleTool(rule string) string {
+	if server, ok := MCPServer(rule); ok {
+		return "mcp__" + server
+	}
+	if r, err := ParseRule(rule); err == nil {
+		return r.Tool
+	}
+	return "(invalid)"
+}
+
+func groupByTool(changes []RuleChange) []ToolChanges {
+	byTool := make(map[string][]RuleChange)
+	for _, c := range changes {
+		c.Tool = ruleTool(c.Rule)
+		byTool[c.Tool] = append(byTool[c.Tool], c)
+	}
+
+	rank := func(c RuleChange) int {
+		for i, k := range changeKinds {
+			if c.Kind == k {
+				return i
+			}
+		}
+		return len(changeKinds)
+	}
+	var tools []ToolChanges
+	for tool, cs := range byTool {
+		sort.Slice(cs, func(i, j int) bool {
+			if rank(cs[i]) != rank(cs[j]) {
+				return rank(cs[i]) < rank(cs[j])
+			}
+			return cs[i].Rule < cs[j].Rule
+		})
+		tools = append(tools, ToolChanges{Tool: tool, Changes: cs})
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Tool < tools[j].Tool })
+	return tools
+}
+
+// diffScalars compares defaultMode, enableAllProjectMcpServers and any
+// single-valued top-level settings claude-hoist doesn't model.
+func diffScalars(before, after Settings) []ScalarChange {
+	var result []ScalarChange
+	add := func(key, from, to string) {
+		if from != to {
+			result = append(result, ScalarChange{Key: key, From: from, To: to})
+		}
+	}
+
+	add("defaultMode", before.Permissions.DefaultMode, after.Permissions.DefaultMode)
+	boolString := func(b *bool) string {
+		if b == nil {
+			return ""
+		}
+		return strconv.FormatBool(*b)
+	}
+	add("enableAllProjectMcpServers", boolString(before.EnableAllProjectMcpServers), boolString(after.EnableAllProjectMcpServers))
+
+	keys := make(map[string]bool)
+	for k := range before.Extra {
+		keys[k] = true
+	}
+	for k := range after.Extra {
+		keys[k] = true
+	}
+	for _, k := range sortedKeys(keys) {
+		from, to := scalarJSON(before.Extra[k]), scalarJSON(after.Extra[k])
+		if from == nil || to == nil {
+			continue // objects and arrays aren't single values
+		}
+		add(k, *from, *to)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// scalarJSON returns a JSON scalar as text, "" for a missing value, or nil
+// for an object or array.
+func scalarJSON(raw json.RawMessage) *string {
+	raw = bytes.TrimSpace(raw)
+	s := string(raw)
+	if len(raw) > 0 && (raw[0] == '{' || raw[0] == '[') {
+		return nil
+	}
+	return &s
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Render prints the diff grouped by tool with counts, followed by the
+// changed settings. Color marks additions green, removals red and other
+// changes yellow.
+func (d SemanticDiff) Render(color bool) string {
+	r := diffRenderer{style: DiffStyle{Color: color}}
+	for _, t := range d.Tools {
+		r.paint(ansiBold, fmt.Sprintf("%s (%s)", t.Tool, countSummary(t.Changes)))
+		r.buf.WriteByte('\n')
+		for _, c := range t.Changes {
+			switch c.Kind {
+			case ChangeAdded:
+				r.paint(ansiGreen, fmt.Sprintf("  + %-5s %s", c.To, c.Rule))
+			case ChangeRemoved:
+				r.paint(ansiRed, fmt.Sprintf("  - %-5s %s", c.From, c.Rule))
+			case ChangeMoved:
+				r.paint(ansiYellow, fmt.Sprintf("  ~ %s → %s  %s", c.From, c.To, c.Rule))
+			case ChangeRedundant:
+				r.paint(ansiFaint, fmt.Sprintf("  = %-5s %s  (now covered by %s)", c.To, c.Rule, c.By))
+			}
+			r.buf.WriteByte('\n')
+		}
+	}
+
+	if len(d.Scalars) > 0 {
+		if len(d.Tools) > 0 {
+			r.buf.WriteByte('\n')
+		}
+		r.paint(ansiBold, "Settings")
+		r.buf.WriteByte('\n')
+		for _, s := range d.Scalars {
+			from, to := s.From, s.To
+			if from == "" {
+				from = "(unset)"
+			}
+			if to == "" {
+				to = "(unset)"
+			}
+			r.paint(ansiYellow, fmt.Sprintf("  ~ %s: %s → %s", s.Key, from, to))
+			r.buf.WriteByte('\n')
+		}
+	}
+
+	if len(d.Tools) > 0 {
+		var all []RuleChange
+		for _, t := range d.Tools {
+			all = append(all, t.Changes...)
+		}
+		fmt.Fprintf(&r.buf, "\n%d rule change(s): %s\n", len(all), countSummary(all))
+	}
+	return r.buf.String()
+}
+
+// countSummary describes changes as e.g. "2 added, 1 moved".
+func countSummary(changes []RuleChange) string {
+	counts := make(map[ChangeKind]int)
+	for _, c := range changes {
+		counts[c.Kind]++
+	}
+	var parts []string
+	for _, k := range changeKinds {
+		if counts[k] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[k], k))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,107 @@
+package hoist
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffSemantic(t *testing.T) {
+	before := Settings{Permissions: Permissions{
+		Allow:       []string{"Bash(ls:*)", "Bash(rm:*)", "Read(./src/**)", "mcp__github__get_issue"},
+		Deny:        []string{"WebFetch"},
+		DefaultMode: "default",
+	}}
+	after := Settings{Permissions: Permissions{
+		// Reordered, with additions; Bash(rm:*) moved to deny and Read now
+		// covers Read(./src/**).
+		Allow:       []string{"Read", "Read(./src/**)", "mcp__github__get_issue", "Bash(ls:*)", "Bash(go test:*)"},
+		Deny:        []string{"Bash(rm:*)"},
+		DefaultMode: "acceptEdits",
+	}}
+
+	d := DiffSemantic(before, after)
+	want := []ToolChanges{
+		{Tool: "Bash", Changes: []RuleChange{
+			{Kind: ChangeAdded, Rule: "Bash(go test:*)", Tool: "Bash", To: ListAllow},
+			{Kind: ChangeMoved, Rule: "Bash(rm:*)", Tool: "Bash", From: ListAllow, To: ListDeny},
+		}},
+		{Tool: "Read", Changes: []RuleChange{
+			{Kind: ChangeAdded, Rule: "Read", Tool: "Read", To: ListAllow},
+			{Kind: ChangeRedundant, Rule: "Read(./src/**)", Tool: "Read", From: ListAllow, To: ListAllow, By: "allow Read"},
+		}},
+		{Tool: "WebFetch", Changes: []RuleChange{
+			{Kind: ChangeRemoved, Rule: "WebFetch", Tool: "WebFetch", From: ListDeny},
+		}},
+	}
+	if !reflect.DeepEqual(d.Tools, want) {
+		t.Errorf("tools:\ngot  %+v\nwant %+v", d.Tools, want)
+	}
+	if len(d.Scalars) != 1 || d.Scalars[0] != (ScalarChange{Key: "defaultMode", From: "default", To: "acceptEdits"}) {
+		t.Errorf("scalars: got %+v", d.Scalars)
+	}
+
+	counts := d.Counts()
+	if counts[ChangeAdded] != 2 || counts[ChangeMoved] != 1 || counts[ChangeRemoved] != 1 || counts[ChangeRedundant] != 1 {
+		t.Errorf("counts: got %v", counts)
+	}
+}
+
+func TestDiffSemanticIgnoresOrder(t *testing.T) {
+	a := Settings{Permissions: Permissions{Allow: []string{"Bash(ls:*)", "Read", "Read"}}}
+	b := Settings{Permissions: Permissions{Allow: []string{"Read", "Bash(ls:*)"}}}
+	if d := DiffSemantic(a, b); !d.Empty() {
+		t.Errorf("reordering should be no change, got %+v", d)
+	}
+}
+
+func TestDiffSemanticScalars(t *testing.T) {
+	on := true
+	before := Settings{Extra: map[string]json.RawMessage{
+		"model":   json.RawMessage(`"sonnet"`),
+		"env":     json.RawMessage(`{"A":"1"}`),
+		"cleanup": json.RawMessage(`30`),
+	}}
+	after := Settings{EnableAllProjectMcpServers: &on, Extra: map[string]json.RawMessage{
+		"model":   json.RawMessage(`"opus"`),
+		"env":     json.RawMessage(`{"A":"2"}`),
+		"cleanup": json.RawMessage(`30`),
+	}}
+
+	got := DiffSemantic(before, after).Scalars
+	want := []ScalarChange{
+		{Key: "enableAllProjectMcpServers", From: "", To: "true"},
+		{Key: "model", From: `"sonnet"`, To: `"opus"`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestSemanticDiffRender(t *testing.T) {
+	before := Settings{Permissions: Permissions{Allow: []string{"Bash(rm:*)"}}}
+	after := Settings{Permissions: Permissions{
+		Allow:       []string{"mcp__github__get_issue"},
+		Deny:        []string{"Bash(rm:*)"},
+		DefaultMode: "plan",
+	}}
+
+	got := DiffSemantic(before, after).Render(false)
+	want := `Bash (1 moved)
+  ~ allow → deny  Bash(rm:*)
+mcp__github (1 added)
+  + allow mcp__github__get_issue
+
+Settings
+  ~ defaultMode: (unset) → plan
+
+2 rule change(s): 1 added, 1 moved
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if colored := DiffSemantic(before, after).Render(true); !strings.Contains(colored, ansiGreen) {
+		t.Errorf("colored output has no color: %q", colored)
+	}
+}
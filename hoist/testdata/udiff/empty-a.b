@@ -0,0 +1,2 @@
+one
+two
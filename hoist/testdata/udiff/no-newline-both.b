@@ -0,0 +1,3 @@
+a
+b
+d
\ No newline at end of file
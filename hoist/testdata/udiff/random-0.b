@@ -0,0 +1,98 @@
+unique 794772
+unique 42450
+word25
+word12
+unique 375441
+word6
+word11
+},
+  "a": 1,
+unique 558433
+unique 849574
+unique 154100
+],
+unique 717209
+word28
+word21
+word20
+word6
+unique 500181
+{
+word26
+word28
+word9
+unique 97802
+unique 418196
+unique 823182
+unique 1198
+unique 868287
+unique 255759
+},
+  "a": 1,
+unique 558433
+unique 849574
+unique 154100
+],
+unique 737822
+unique 200348
+unique 232473
+word2
+unique 469730
+word13
+unique 978147
+word12
+word0
+word27
+word9
+word28
+word21
+word17
+word4
+word8
+word5
+unique 34574
+unique 688557
+],
+unique 254841
+word10
+edit 343
+word3
+[
+word2
+edit 921
+word1
+unique 968235
+
+unique 967922
+unique 716700
+word8
+unique 938516
+unique 865351
+unique 607854
+word24
+unique 734239
+unique 469730
+word13
+unique 978147
+word12
+word0
+word27
+unique 831861
+word13
+unique 510073
+word14
+unique 254841
+word10
+word7
+word3
+[
+unique 229400
+word29
+unique 982012
+],
+unique 603930
+}
+unique 502512
+[
+unique 23889
+  "a": 1,
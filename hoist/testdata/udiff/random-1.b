@@ -0,0 +1,305 @@
+],
+word24
+unique 495185
+unique 827036
+word24
+word9
+word17
+{
+unique 279267
+unique 239874
+  "a": 1,
+unique 32075
+word27
+word17
+unique 442621
+unique 553259
+word21
+unique 579715
+word7
+unique 797911
+word11
+unique 436396
+unique 583484
+unique 104857
+word11
+word14
+unique 745738
+word20
+word5
+word24
+unique 529828
+},
+word18
+word4
+edit 20
+word25
+word3
+word18
+edit 932
+word12
+unique 644675
+word18
+unique 176783
+{
+unique 565829
+unique 574974
+word25
+word10
+word29
+word10
+unique 638524
+unique 5986
+word25
+unique 543873
+unique 215466
+[
+word16
+word5
+unique 433481
+word15
+{
+word14
+}
+unique 666234
+word4
+unique 837223
+word9
+],
+}
+word10
+word0
+unique 193577
+],
+word9
+word3
+unique 679689
+unique 476789
+unique 520611
+word9
+}
+word14
+word5
+edit 959
+unique 534895
+unique 635068
+word3
+word18
+word3
+word25
+unique 571161
+unique 661412
+unique 541693
+word26
+unique 414080
+edit 834
+word20
+word12
+word6
+unique 321269
+],
+word12
+unique 436388
+word1
+},
+word6
+word25
+word5
+word6
+word20
+word24
+word17
+word24
+word18
+unique 18971
+word13
+unique 590705
+unique 355567
+word10
+unique 878393
+word28
+word27
+word27
+},
+word3
+word27
+word14
+unique 881991
+word14
+edit 664
+word24
+word28
+unique 336305
+],
+word2
+unique 357456
+word17
+word28
+
+unique 382616
+unique 591865
+word0
+word10
+},
+unique 12983
+unique 15254
+word0
+unique 828247
+word8
+unique 615296
+word0
+edit 416
+  "a": 1,
+word17
+unique 308306
+word23
+word6
+unique 41544
+word11
+unique 335807
+word13
+],
+unique 630662
+unique 116771
+word27
+unique 491698
+unique 271671
+word6
+word8
+word10
+word29
+unique 986431
+word12
+word4
+word12
+  "a": 1,
+
+}
+unique 421290
+word28
+unique 764589
+{
+word15
+word2
+word13
+word4
+word2
+unique 861457
+unique 320468
+word25
+unique 631130
+word6
+},
+unique 860912
+unique 842904
+unique 579811
+unique 782431
+unique 215413
+word20
+[
+unique 700216
+],
+unique 468393
+unique 575951
+edit 866
+unique 475329
+word14
+word24
+word19
+unique 19829
+word1
+word16
+word12
+word15
+word1
+word10
+word18
+
+{
+word13
+word21
+unique 670156
+unique 249953
+word23
+unique 747473
+word28
+unique 763594
+unique 288594
+unique 230130
+],
+unique 676633
+unique 167214
+word6
+word12
+unique 389665
+word22
+word0
+unique 538916
+word4
+word20
+word29
+unique 820268
+word18
+unique 364885
+word3
+},
+
+
+unique 145884
+unique 646869
+unique 691763
+unique 85991
+word8
+unique 400926
+unique 947310
+word3
+unique 459411
+word24
+unique 124976
+word27
+word28
+[
+word5
+}
+word8
+unique 216641
+word2
+word10
+edit 479
+unique 468080
+unique 847935
+unique 176139
+word24
+word0
+unique 598259
+unique 214771
+  "a": 1,
+unique 25323
+{
+word1
+word16
+word12
+word15
+unique 339411
+word21
+word18
+word29
+word17
+word28
+word10
+unique 757373
+unique 869711
+unique 208540
+unique 484002
+unique 542022
+word12
+unique 471217
+unique 556747
+word26
+word17
+word25
+word15
+word3
+word18
+}
+word18
@@ -0,0 +1,738 @@
+unique 96033
+word3
+unique 702263
+unique 263804
+unique 636277
+word3
+unique 669485
+word25
+unique 570610
+unique 526455
+},
+unique 381696
+word13
+unique 444188
+unique 551291
+word4
+}
+word4
+word25
+word25
+unique 190676
+unique 467284
+unique 770075
+word16
+unique 370972
+word21
+word18
+unique 483819
+word24
+word15
+unique 476775
+unique 483406
+word28
+unique 510246
+unique 986286
+word3
+unique 646285
+word23
+word25
+word25
+unique 616504
+word6
+word16
+unique 653540
+unique 822377
+unique 761213
+word5
+unique 111313
+[
+word7
+unique 960328
+word26
+word10
+word6
+unique 63318
+},
+word16
+}
+],
+}
+word1
+unique 164750
+unique 548501
+unique 404295
+word8
+},
+word0
+word24
+word21
+},
+unique 792369
+word2
+word7
+word13
+unique 25379
+word1
+word18
+word13
+word14
+word19
+unique 733451
+word1
+unique 265255
+word3
+word22
+unique 532948
+unique 742489
+unique 258747
+word21
+
+word16
+word20
+{
+word17
+word0
+
+  "a": 1,
+word7
+}
+word22
+word27
+unique 222769
+unique 797468
+unique 764339
+unique 446272
+[
+unique 974001
+word4
+word4
+unique 695512
+unique 383971
+word0
+unique 303647
+unique 977741
+}
+unique 432279
+word12
+}
+unique 62904
+word24
+],
+}
+word24
+word29
+word22
+word15
+word0
+word8
+word0
+word14
+unique 222308
+unique 25867
+unique 493223
+unique 758663
+unique 304876
+word8
+word22
+word16
+word26
+unique 754499
+unique 843422
+word24
+unique 311179
+word3
+word9
+
+word2
+word29
+word15
+word27
+word19
+unique 837361
+},
+word11
+word14
+word26
+word2
+word20
+word26
+word25
+word3
+word8
+word15
+unique 601295
+unique 489327
+}
+unique 401868
+unique 189188
+[
+word18
+word19
+unique 495451
+word28
+
+unique 893463
+unique 558738
+unique 422286
+unique 401138
+unique 665668
+unique 328135
+word22
+unique 855711
+word18
+edit 832
+word17
+unique 927653
+unique 408928
+unique 804258
+unique 287739
+unique 614704
+word24
+unique 144570
+word20
+word25
+word8
+word22
+unique 797084
+{
+unique 559065
+unique 690384
+word24
+unique 967048
+word10
+unique 482681
+
+unique 364523
+word18
+word1
+word24
+word22
+unique 163418
+word28
+{
+word27
+unique 591718
+word12
+word27
+unique 317412
+word13
+word12
+unique 412055
+
+word6
+word10
+  "a": 1,
+unique 491666
+[
+word16
+word1
+word26
+word5
+unique 398645
+unique 888669
+word10
+unique 849165
+unique 898866
+word14
+[
+word7
+unique 156802
+unique 659386
+},
+word22
+word26
+word0
+word17
+unique 224098
+],
+word16
+word21
+unique 483458
+unique 966517
+unique 670690
+word10
+  "a": 1,
+unique 491666
+[
+word16
+word1
+unique 244498
+word28
+unique 419703
+unique 785820
+word25
+word13
+unique 787487
+unique 738730
+unique 790137
+unique 751162
+],
+word18
+word9
+unique 371199
+
+unique 195507
+word17
+}
+word3
+word20
+word13
+word17
+word23
+unique 157893
+word5
+unique 99662
+unique 821957
+word9
+word16
+word14
+word8
+unique 49128
+word16
+unique 641153
+
+],
+word10
+word26
+word0
+word18
+[
+word23
+word13
+
+word27
+unique 519789
+unique 881633
+unique 177631
+word26
+  "a": 1,
+word1
+word25
+unique 81093
+word22
+unique 9275
+word15
+}
+
+word22
+word21
+unique 628352
+unique 837978
+unique 467592
+word1
+word23
+word23
+unique 653540
+unique 822377
+unique 761213
+word5
+unique 111313
+[
+word7
+}
+word25
+[
+{
+unique 548284
+word26
+unique 146765
+{
+word0
+word22
+[
+unique 656803
+word18
+unique 752442
+word3
+word2
+unique 220530
+word5
+word20
+word1
+word12
+unique 589784
+word10
+word24
+unique 240231
+word1
+unique 690203
+word28
+unique 222654
+},
+unique 655813
+word9
+unique 498231
+word7
+unique 627150
+word5
+unique 147238
+unique 570720
+unique 799937
+unique 720123
+unique 486414
+unique 219011
+word13
+unique 290814
+unique 543570
+word19
+unique 700204
+unique 477479
+word0
+unique 769935
+}
+word1
+word14
+word5
+word1
+unique 824428
+unique 913775
+word4
+unique 695512
+unique 383971
+word0
+word15
+word9
+unique 609029
+word5
+unique 891323
+word12
+word16
+unique 901961
+word29
+unique 715288
+unique 911376
+word12
+}
+word13
+word27
+word14
+unique 75493
+}
+word29
+word2
+word17
+unique 663920
+word10
+unique 519598
+word8
+word12
+unique 897837
+unique 212770
+unique 967632
+unique 620046
+unique 410939
+word8
+unique 588422
+word8
+word18
+unique 125199
+unique 410841
+word17
+word8
+word27
+
+unique 569935
+unique 56438
+word20
+word25
+word16
+word26
+unique 516701
+word22
+unique 231878
+unique 974001
+word4
+word4
+}
+word1
+unique 216523
+word27
+word2
+unique 988437
+unique 672647
+
+unique 143015
+word1
+word25
+unique 496206
+word28
+word14
+unique 102050
+unique 112243
+unique 365018
+word10
+unique 939473
+word2
+word14
+{
+word27
+unique 904036
+unique 723936
+word20
+word8
+},
+unique 170493
+unique 16670
+unique 309080
+},
+unique 593411
+unique 938159
+],
+unique 116245
+unique 957014
+word7
+  "a": 1,
+word18
+word10
+word7
+word26
+unique 965710
+word1
+word1
+word16
+word29
+word21
+unique 628352
+unique 837978
+unique 467592
+word1
+unique 152706
+word4
+unique 668821
+word9
+unique 492866
+word20
+word7
+},
+unique 651732
+unique 447245
+}
+unique 574463
+word9
+unique 259132
+word22
+word22
+unique 258205
+word27
+word11
+unique 791467
+word0
+word8
+unique 684793
+unique 405627
+word22
+unique 476703
+word17
+word16
+unique 584077
+word2
+word4
+word24
+unique 757115
+unique 426770
+word13
+word18
+word9
+unique 699831
+},
+  "a": 1,
+word16
+word13
+unique 187308
+unique 969745
+
+word23
+word8
+  "a": 1,
+unique 361307
+word24
+[
+word18
+unique 669914
+unique 213459
+word13
+word12
+unique 410337
+word24
+word10
+word6
+word8
+word16
+unique 190009
+unique 565308
+unique 756904
+unique 219696
+word8
+word10
+word25
+unique 1634
+word23
+unique 680927
+word5
+unique 636654
+unique 884951
+unique 140087
+unique 737917
+
+word5
+unique 286239
+}
+word0
+word29
+word20
+word17
+word22
+word22
+unique 671875
+word2
+unique 563870
+word26
+unique 306556
+}
+word28
+word19
+unique 195387
+word22
+unique 898866
+word14
+[
+word7
+unique 367980
+unique 843441
+unique 113355
+unique 162929
+unique 233147
+word0
+word16
+unique 529133
+unique 893177
+unique 998496
+unique 142269
+  "a": 1,
+word20
+unique 205524
+}
+unique 754326
+word27
+unique 143541
+unique 517202
+unique 709701
+word11
+word2
+
+unique 369361
+unique 746884
+word12
+unique 711334
+unique 561779
+word7
+word7
+unique 94589
+unique 699732
+word19
+unique 39269
+unique 350399
+word24
+unique 361590
+unique 688679
+unique 131338
+unique 396320
+word7
+unique 645273
+edit 66
+word29
+unique 185914
+],
+word13
+word8
+word22
+unique 478092
+word7
+  "a": 1,
+word29
+word9
+word8
+word7
+word3
+unique 977601
+unique 985845
+word2
+unique 676229
+word20
+word0
+  "a": 1,
+unique 199092
+word5
+unique 398645
+unique 888669
+word10
+unique 849165
+word7
+unique 992608
+unique 323986
+word24
+word17
+unique 412649
+word29
+word20
+word13
+word19
+unique 482375
+unique 935673
+word27
+unique 526051
+word26
+unique 165268
+unique 388006
+unique 770276
+word6
+word25
+unique 532267
+word3
+unique 322898
+unique 148276
+unique 96912
+unique 712313
+word28
+word28
+word18
+word12
+word3
+unique 829957
+word5
+word7
+word9
+word9
+word18
+[
+word24
+word13
+unique 768117
+unique 677285
+unique 482375
+unique 935673
+unique 526051
+word26
+unique 165268
+unique 388006
+word14
+[
+word9
+unique 466013
+word25
+unique 49862
+unique 11795
+word7
+word23
+word11
+word4
+unique 57192
+],
+unique 609500
+}
+word29
+unique 499327
+word14
+{
+}
@@ -0,0 +1,1504 @@
+word27
+word23
+unique 68711
+unique 952965
+unique 271952
+word5
+unique 493107
+word28
+word2
+word2
+unique 548595
+{
+unique 67141
+},
+}
+unique 282519
+word17
+unique 965841
+word29
+word1
+unique 102188
+word24
+word20
+unique 896769
+word25
+unique 601906
+word19
+word14
+unique 974070
+word10
+unique 703881
+unique 732551
+unique 568082
+unique 596752
+word6
+unique 601392
+word0
+word23
+],
+word2
+word20
+unique 915162
+},
+word14
+word10
+},
+],
+word27
+word5
+unique 305777
+unique 163786
+unique 909932
+word16
+unique 940215
+unique 395052
+word26
+word28
+word25
+word8
+unique 458695
+unique 546441
+word14
+word19
+unique 330171
+word1
+word14
+word15
+unique 292473
+unique 23260
+}
+unique 263320
+unique 313142
+word13
+word4
+word16
+unique 276967
+word17
+}
+unique 716945
+unique 325076
+word10
+unique 681090
+unique 106788
+unique 995254
+word7
+word3
+word6
+unique 473014
+word0
+word5
+word29
+word10
+
+unique 362055
+word19
+word10
+word19
+word29
+word19
+{
+word25
+word7
+word22
+unique 695192
+unique 303082
+word7
+unique 899658
+word11
+word8
+word25
+unique 940118
+unique 450768
+{
+word0
+word12
+}
+word19
+word0
+word9
+unique 567259
+[
+word5
+word0
+word10
+unique 845205
+{
+word29
+unique 52357
+unique 260318
+word26
+[
+{
+unique 812903
+word0
+word23
+unique 895954
+unique 540440
+word13
+],
+word17
+word16
+word14
+word1
+word17
+unique 594263
+word16
+word27
+},
+unique 452558
+word24
+unique 330247
+word19
+word8
+unique 75058
+unique 235343
+}
+unique 392414
+unique 586232
+unique 274915
+word0
+unique 767062
+unique 930447
+word17
+unique 769377
+word27
+{
+word8
+unique 46465
+word29
+word17
+}
+word0
+word0
+unique 876800
+unique 298314
+
+word29
+word8
+  "a": 1,
+unique 64109
+word29
+],
+word4
+unique 476201
+unique 790147
+unique 265180
+word18
+unique 583677
+
+word8
+unique 432885
+unique 168422
+word29
+unique 706589
+unique 718861
+word18
+unique 156581
+word24
+unique 961139
+unique 996872
+word4
+word5
+word25
+word7
+unique 564170
+unique 310235
+unique 896840
+word10
+unique 322151
+word23
+unique 210369
+word16
+word23
+unique 150586
+word23
+unique 215751
+word28
+],
+unique 419705
+unique 924687
+unique 490099
+unique 934269
+],
+unique 895359
+word5
+unique 144157
+},
+unique 267395
+},
+word20
+
+word9
+unique 305936
+word21
+word14
+word14
+word17
+word6
+unique 778430
+unique 409909
+word13
+word10
+word20
+word26
+unique 101612
+word16
+unique 386329
+unique 309889
+unique 702510
+unique 845209
+unique 112371
+unique 354974
+unique 562212
+word24
+[
+unique 711504
+unique 778319
+word2
+word22
+word28
+word14
+unique 681143
+word28
+word4
+word12
+unique 739735
+word4
+unique 569518
+word18
+word10
+[
+unique 44156
+word10
+word25
+word14
+unique 470005
+],
+word0
+  "a": 1,
+unique 591772
+unique 762618
+word5
+word18
+unique 785069
+word2
+unique 836968
+word26
+word4
+word9
+word11
+word21
+unique 995437
+unique 331294
+word12
+unique 554778
+unique 313947
+unique 700072
+word5
+unique 2488
+word7
+word26
+unique 208569
+word26
+word25
+unique 992718
+word29
+word2
+
+unique 53065
+word7
+word24
+word14
+word15
+unique 140883
+},
+unique 775652
+unique 845865
+],
+unique 458253
+unique 231619
+  "a": 1,
+unique 428318
+word5
+unique 410511
+],
+word20
+word24
+word22
+word18
+word22
+unique 754651
+word16
+word16
+word7
+unique 843258
+word16
+word22
+word3
+word3
+word25
+}
+word3
+word4
+}
+word13
+[
+},
+}
+unique 925975
+  "a": 1,
+word5
+word0
+word0
+word11
+word22
+unique 315147
+word28
+word14
+unique 361485
+[
+unique 93726
+{
+
+word25
+[
+word25
+unique 210437
+unique 794202
+word23
+unique 985755
+unique 400884
+word18
+word11
+word19
+word17
+word27
+unique 973623
+word17
+unique 580215
+word4
+word19
+word21
+unique 503961
+word3
+word17
+unique 44809
+}
+unique 96464
+unique 710233
+unique 335633
+[
+unique 914628
+word22
+unique 768457
+unique 388070
+word5
+  "a": 1,
+word12
+
+unique 220619
+[
+unique 980889
+unique 614001
+unique 117554
+word6
+word28
+word25
+unique 633710
+word27
+unique 490193
+
+word0
+  "a": 1,
+word9
+word22
+  "a": 1,
+unique 798387
+unique 733456
+word21
+unique 306044
+unique 623269
+word17
+word27
+word12
+unique 80990
+word5
+],
+unique 719686
+unique 443392
+unique 70259
+unique 224570
+[
+word20
+word9
+word3
+unique 216985
+unique 917211
+unique 770838
+unique 976586
+unique 893979
+word5
+unique 995732
+word19
+},
+}
+  "a": 1,
+word2
+word26
+word23
+word6
+word20
+word22
+unique 783067
+unique 149901
+word12
+unique 465407
+word2
+word11
+unique 845166
+unique 390679
+unique 452244
+word22
+word27
+word11
+word22
+word15
+unique 311845
+unique 975312
+unique 10546
+word26
+}
+[
+word22
+word16
+unique 36829
+word8
+word20
+unique 876119
+word27
+unique 471878
+word8
+unique 522829
+word9
+}
+word3
+unique 847842
+word1
+unique 882252
+unique 533547
+unique 353836
+word10
+},
+[
+unique 950178
+word26
+unique 973727
+unique 631921
+word16
+word3
+],
+unique 872609
+word19
+unique 465684
+word9
+word26
+word13
+},
+word7
+unique 976565
+word10
+word29
+word0
+word10
+unique 790364
+unique 107434
+],
+word25
+word21
+word15
+word17
+unique 415840
+word10
+},
+unique 332812
+unique 582145
+word1
+word19
+unique 83111
+word5
+unique 732105
+word0
+unique 857534
+unique 987894
+],
+word23
+word11
+[
+word24
+unique 151222
+word4
+unique 490036
+unique 678356
+word18
+word4
+word1
+unique 51170
+word2
+word17
+unique 454324
+}
+word1
+word11
+word15
+unique 632999
+word5
+word16
+unique 501624
+word15
+word24
+word13
+word15
+word21
+word22
+word12
+word27
+word17
+word11
+unique 889553
+unique 315344
+unique 880848
+unique 332295
+word5
+[
+word4
+{
+word0
+unique 596575
+unique 550745
+unique 288497
+word27
+word13
+word6
+word25
+word13
+word15
+unique 875797
+word12
+word0
+unique 232054
+unique 357578
+unique 260698
+word10
+word23
+word4
+unique 554150
+[
+word20
+word11
+word17
+],
+word6
+word24
+unique 452035
+unique 408509
+unique 379081
+word16
+word18
+  "a": 1,
+word2
+{
+{
+
+word20
+}
+word13
+word16
+unique 878388
+unique 223375
+word0
+word17
+word1
+unique 300686
+word0
+unique 627938
+unique 451664
+word15
+word24
+unique 192581
+word1
+word25
+unique 614533
+unique 691895
+word11
+
+word13
+{
+word20
+unique 98748
+unique 347681
+unique 924389
+word13
+word18
+unique 54413
+unique 27848
+unique 533633
+word29
+unique 188730
+word23
+unique 962812
+word11
+word22
+unique 554047
+word29
+word1
+word16
+unique 372096
+word26
+[
+unique 899386
+word5
+word6
+  "a": 1,
+unique 125546
+unique 525593
+word6
+},
+word8
+[
+word16
+word4
+word15
+word14
+unique 217714
+word21
+word3
+word6
+word16
+unique 184298
+unique 556464
+word27
+word4
+unique 463827
+word15
+
+unique 901390
+}
+word10
+word19
+unique 232663
+word25
+unique 362760
+word23
+unique 120384
+unique 355333
+unique 151252
+  "a": 1,
+word2
+unique 501008
+unique 159317
+unique 468350
+},
+word5
+unique 736103
+unique 579427
+word11
+word19
+unique 784386
+unique 968610
+word28
+unique 367700
+word16
+word8
+word5
+word17
+unique 339784
+word13
+unique 509539
+word18
+word19
+word16
+[
+unique 735942
+word25
+unique 124943
+word27
+word17
+unique 540568
+word10
+word10
+unique 241647
+unique 122896
+word0
+word20
+word16
+unique 195122
+word25
+unique 699336
+word9
+unique 769072
+  "a": 1,
+word1
+unique 236317
+unique 350201
+unique 342641
+unique 148799
+word24
+word25
+word21
+unique 528641
+word29
+word20
+word27
+word17
+},
+{
+word1
+word6
+unique 762703
+unique 269528
+word28
+word15
+word3
+],
+word11
+word27
+word3
+
+unique 927308
+unique 99995
+
+unique 543749
+word22
+word21
+{
+word19
+},
+word13
+unique 379384
+word6
+word27
+word13
+unique 462530
+{
+word7
+word18
+word13
+unique 120234
+word8
+}
+word14
+word22
+word9
+unique 630584
+word6
+word20
+unique 838864
+word21
+  "a": 1,
+word2
+word1
+word26
+word16
+word11
+word24
+word8
+unique 807410
+{
+{
+word25
+unique 949669
+word15
+unique 319528
+unique 515290
+word12
+unique 324019
+unique 92870
+},
+word5
+word3
+unique 556672
+unique 757690
+word3
+word12
+word25
+],
+word24
+unique 442196
+unique 348922
+word18
+word14
+unique 30286
+word23
+unique 270661
+unique 784089
+word15
+word9
+unique 441211
+word5
+unique 629141
+word8
+unique 50954
+unique 253208
+word13
+word4
+unique 474154
+word19
+word14
+},
+word18
+word18
+word7
+word5
+unique 32488
+unique 763088
+unique 633783
+unique 501506
+word25
+word20
+unique 497363
+word28
+unique 959464
+word29
+unique 839226
+unique 102118
+unique 144578
+word25
+unique 127964
+word6
+unique 307252
+word1
+unique 456236
+unique 165308
+word20
+word21
+}
+}
+word14
+word17
+word0
+
+word17
+word7
+unique 350127
+
+word12
+unique 873540
+word28
+unique 1743
+word12
+unique 251705
+unique 789657
+],
+unique 360609
+word2
+word6
+unique 958565
+unique 819215
+word20
+word27
+unique 278403
+word26
+word6
+
+word18
+unique 833571
+unique 622216
+word0
+unique 128154
+word13
+word3
+[
+word13
+unique 71411
+word14
+}
+word21
+unique 176582
+word0
+unique 387377
+unique 502572
+word26
+word22
+word16
+unique 767228
+word27
+word29
+word21
+],
+unique 195181
+word27
+word4
+word9
+word16
+word7
+unique 919578
+[
+unique 173088
+word25
+word24
+unique 482498
+unique 917722
+unique 777079
+
+unique 211359
+unique 294189
+word14
+word6
+word21
+word25
+unique 758554
+word21
+unique 650093
+word13
+unique 14966
+unique 736186
+{
+word20
+word15
+word26
+word23
+word12
+unique 295095
+word3
+word14
+word17
+word27
+word14
+word3
+],
+word17
+unique 623086
+word25
+word6
+unique 656283
+word16
+[
+unique 480444
+word9
+unique 519271
+word24
+unique 778163
+word12
+],
+unique 820822
+word3
+word9
+unique 588723
+unique 274638
+word4
+unique 655468
+unique 477117
+unique 124452
+word5
+unique 703808
+word13
+word24
+word13
+unique 187256
+unique 613206
+word12
+unique 544067
+word8
+word28
+word27
+{
+word23
+  "a": 1,
+word16
+word8
+word25
+unique 65029
+word27
+word13
+word11
+word15
+unique 858355
+word17
+word20
+unique 424693
+word18
+unique 976960
+unique 810769
+unique 962956
+word12
+{
+  "a": 1,
+},
+word20
+unique 720191
+word20
+word9
+unique 192620
+word15
+unique 775992
+word7
+unique 373798
+unique 244006
+unique 854487
+word15
+word3
+{
+word3
+word5
+word23
+unique 796126
+unique 615069
+unique 464037
+unique 173197
+unique 424391
+unique 400103
+unique 411092
+word17
+unique 220947
+unique 694162
+word15
+word17
+unique 811143
+word15
+word11
+unique 337836
+unique 795527
+unique 762599
+word18
+unique 223869
+unique 226532
+word16
+unique 276290
+word0
+unique 116554
+}
+unique 607809
+},
+unique 868050
+unique 612620
+unique 326987
+unique 131459
+unique 138414
+word4
+unique 495808
+unique 705259
+word26
+word23
+word15
+word29
+unique 913443
+word19
+word0
+unique 329759
+word12
+word18
+unique 486755
+unique 74517
+unique 459048
+unique 120769
+],
+unique 618617
+word10
+unique 865696
+word13
+unique 51380
+unique 601686
+word5
+unique 686015
+unique 210351
+word27
+unique 177077
+word25
+  "a": 1,
+unique 217228
+word19
+unique 114262
+],
+word12
+unique 889026
+word21
+word15
+word24
+word2
+word7
+unique 291222
+[
+unique 242045
+unique 862174
+unique 922626
+word4
+}
+word8
+unique 897704
+word1
+word13
+unique 584472
+unique 982174
+
+word25
+word0
+word29
+unique 634818
+unique 237225
+unique 924376
+unique 280458
+word22
+unique 932988
+unique 428149
+word17
+word8
+word3
+word23
+word4
+word14
+word22
+  "a": 1,
+unique 376589
+[
+word15
+],
+word0
+word11
+unique 651027
+unique 662029
+word19
+unique 403051
+word14
+word28
+],
+word12
+word17
+word9
+word6
+word25
+unique 408032
+],
+unique 990270
+unique 573705
+unique 715703
+word7
+word18
+word11
+unique 851696
+unique 160556
+unique 657860
+unique 591337
+word4
+word22
+word19
+word18
+unique 538157
+word18
+word12
+word13
+unique 83691
+unique 403479
+unique 272415
+unique 311493
+word7
+word29
+unique 908386
+  "a": 1,
+word24
+word21
+word11
+unique 305977
+word5
+unique 44096
+word26
+word17
+unique 451287
+unique 161437
+unique 395103
+unique 431048
+word8
+word4
+unique 118359
+unique 369410
+unique 954514
+unique 831456
+unique 364068
+unique 324611
+[
+unique 615541
+word14
+unique 214398
+},
+unique 987813
+unique 86464
+word13
+],
+word18
+}
+word28
+word18
+word20
+unique 99991
+word0
+}
+word13
+word28
+word7
+unique 357168
+word8
+word13
+word15
+word14
+unique 951452
+unique 464017
+word4
+
+unique 136985
+word12
+unique 545673
+  "a": 1,
+word5
+],
+unique 591334
+word13
+unique 211538
+word29
+word2
+word13
+unique 792460
+word4
+{
+word10
+unique 49897
+word16
+word12
+word29
+  "a": 1,
+unique 741323
+unique 731956
+unique 606686
+unique 236918
+word12
+unique 726201
+unique 384695
+unique 499423
+unique 535602
+word4
+word2
+[
+unique 287201
+word8
+word9
+word21
+unique 155715
+word16
+word26
+word12
+unique 445228
+unique 84816
+unique 244403
+unique 599919
+word29
+unique 113639
+unique 540270
+word21
+word13
+word11
+],
+unique 124361
+unique 92454
+unique 993810
+word3
+unique 475047
+word14
+unique 734173
+unique 412986
+word17
+unique 581989
+word9
+word15
+
+word6
+unique 78574
+word21
+word26
+unique 251863
+word25
+unique 539733
+word6
+word28
+unique 632677
+word23
+unique 669273
+unique 23966
+unique 522160
+word14
+word14
+unique 367465
+unique 263458
+word21
+  "a": 1,
+word28
+word26
+word21
+unique 469395
+word25
+[
+word10
+unique 986034
+unique 359376
+word8
+word28
+{
+word24
+word11
+unique 866647
+unique 732475
+unique 285165
+[
+unique 444558
+word4
+word2
+unique 366303
+word11
+word18
+word13
+unique 7227
+word21
+word12
+unique 453637
+unique 137617
+word0
+word0
+word1
+unique 824935
+unique 612088
+unique 582653
+word22
+unique 900951
+unique 511288
+word6
+unique 332842
+word15
+unique 822107
+unique 720174
+word5
+word16
+word10
+unique 439103
+word22
+unique 481143
+{
+word21
+word26
+word11
+unique 376750
+word9
+word0
+[
+unique 391937
+word22
+word13
+{
+unique 214365
+word22
+word29
+word13
+word15
+unique 210945
+unique 519621
+unique 248677
+unique 527335
+unique 222140
+],
+unique 276218
+unique 478935
+word1
+word23
+unique 441014
+unique 734451
+word8
+
+unique 78373
+unique 779959
+}
+unique 140100
+unique 991307
+
+unique 362055
+word19
+word10
+word19
+unique 892945
+unique 53232
+unique 745453
+word23
+unique 450069
+word12
+word10
+unique 375274
+edit 719
+unique 587019
+word26
+unique 910778
+unique 73830
+word28
+word27
+unique 688425
+{
+word13
+word13
+word1
+word10
+unique 881978
+unique 478144
+word25
+word29
+
+unique 671263
+word6
+word5
+}
+unique 801262
+unique 909544
+unique 589400
+word27
+unique 627500
+word6
+word24
+unique 681663
+word16
+word29
+word19
+word6
+word5
+unique 389965
+unique 789093
+unique 817661
+word13
+word26
+unique 199177
+word13
+word15
+word20
+unique 950004
+unique 424105
+unique 393791
+unique 788281
+unique 413967
+word11
+word21
+  "a": 1,
+word16
+word9
+unique 285416
+unique 591488
+unique 501260
+unique 612728
+word25
+unique 302451
+unique 516359
+unique 602377
+word5
+word20
+word21
+unique 158816
+word11
+unique 961205
+word3
+word25
+
+unique 636754
+word13
+word18
@@ -0,0 +1,415 @@
+  "a": 1,
+unique 502140
+],
+word28
+unique 839335
+unique 61705
+word27
+word4
+unique 274432
+}
+unique 845836
+word10
+word12
+word16
+word14
+unique 530530
+word8
+
+unique 857612
+unique 574240
+unique 7561
+unique 600170
+unique 326906
+unique 533067
+word20
+word20
+word7
+},
+word22
+unique 294055
+word23
+unique 152109
+unique 205073
+word5
+unique 462581
+word15
+word13
+unique 208364
+unique 105884
+unique 742562
+word8
+word4
+}
+
+unique 299563
+unique 343012
+word11
+word2
+unique 430364
+unique 911743
+unique 857659
+word5
+unique 306179
+unique 35962
+word2
+word8
+word17
+word3
+{
+word22
+word16
+unique 599214
+word6
+word6
+[
+word3
+word2
+unique 572763
+word0
+word8
+},
+word26
+word19
+unique 209989
+word8
+word24
+word19
+word20
+unique 522798
+},
+word8
+word7
+word9
+[
+unique 329794
+word29
+},
+
+word29
+unique 969489
+word11
+unique 838341
+unique 330340
+unique 553673
+word10
+word18
+unique 77993
+unique 293686
+unique 700817
+word18
+unique 133832
+unique 282529
+unique 63421
+unique 721265
+unique 596855
+word18
+unique 229157
+unique 221454
+unique 13747
+unique 269839
+word17
+unique 577354
+word5
+word14
+unique 589689
+unique 494414
+unique 460998
+},
+unique 118401
+word9
+unique 145502
+
+unique 548390
+unique 311362
+unique 867761
+word27
+unique 19922
+word2
+word6
+unique 326284
+word24
+unique 419491
+word0
+unique 837134
+unique 537368
+unique 436095
+unique 814309
+word5
+unique 974913
+unique 442986
+word28
+word3
+word2
+word14
+{
+unique 5832
+unique 126828
+word24
+unique 630190
+],
+word19
+unique 374122
+word4
+unique 657143
+[
+unique 328490
+word22
+unique 595946
+word25
+unique 642776
+unique 410838
+word16
+unique 356561
+
+unique 708195
+unique 186299
+word16
+word15
+unique 926142
+word18
+unique 948211
+word16
+word12
+unique 633098
+
+unique 846463
+unique 103177
+word24
+unique 983471
+word0
+word23
+unique 781400
+word12
+unique 721167
+word8
+unique 737767
+word16
+word13
+word26
+unique 942688
+unique 530878
+word13
+word19
+word9
+word27
+word28
+unique 381998
+word17
+unique 163632
+word22
+unique 661206
+unique 633133
+unique 954470
+word9
+word23
+unique 815707
+word8
+word1
+unique 171253
+word28
+unique 564653
+word26
+  "a": 1,
+word13
+word10
+word11
+unique 662176
+unique 766851
+word19
+unique 921154
+unique 85257
+{
+unique 579185
+word19
+word12
+],
+word3
+unique 268509
+word27
+unique 903449
+word3
+word20
+unique 292133
+unique 19922
+word2
+word0
+unique 863394
+word7
+word23
+unique 558092
+word21
+unique 740775
+unique 716774
+unique 397890
+word4
+unique 615752
+word23
+word3
+unique 735074
+edit 72
+unique 933813
+unique 801455
+unique 956025
+word19
+unique 39034
+unique 222299
+word12
+unique 500676
+unique 355621
+unique 87356
+unique 936583
+  "a": 1,
+unique 732671
+word13
+word15
+unique 185740
+unique 522280
+word8
+],
+word1
+word10
+unique 122453
+word25
+unique 74965
+unique 653642
+word22
+}
+unique 35962
+word2
+word8
+unique 784745
+word12
+unique 662899
+unique 35695
+word24
+unique 790669
+word25
+word11
+unique 738789
+unique 337478
+}
+word20
+unique 289349
+word19
+unique 972752
+unique 336810
+unique 220374
+word25
+word18
+word11
+unique 672670
+word13
+word3
+unique 735074
+edit 72
+unique 933813
+unique 801455
+word14
+word29
+unique 174555
+word26
+word2
+unique 119541
+unique 331454
+word29
+word2
+unique 79389
+word8
+unique 187979
+unique 783219
+word4
+unique 275699
+word13
+unique 771697
+unique 636280
+unique 170242
+word6
+[
+unique 717349
+unique 973921
+word9
+unique 700530
+unique 517852
+word5
+unique 411347
+word27
+word13
+word18
+word12
+unique 795362
+unique 190034
+word7
+word16
+word5
+unique 710388
+word16
+word0
+unique 199895
+unique 194029
+unique 222938
+word0
+word13
+unique 521489
+unique 996844
+word18
+word17
+unique 367671
+word21
+unique 469176
+word19
+word14
+word28
+
+word26
+word8
+unique 628329
+}
+word7
+unique 144964
+unique 140482
+word11
+word13
+word16
+word15
+unique 877411
+word29
+unique 270539
+word27
+word2
+word5
+word0
+word5
+unique 150220
+word1
+[
+word15
+unique 84442
+unique 49340
+unique 633921
+word4
+[
+word10
+word23
+unique 555272
+unique 65477
+unique 301826
+unique 47179
+word26
+unique 961884
+unique 75546
+
+unique 827227
+unique 297298
+unique 211755
+unique 782564
+unique 97709
+{
+unique 545045
+word20
+word26
+unique 700176
+word5
+word28
+[
+word20
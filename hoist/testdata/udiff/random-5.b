@@ -0,0 +1,2001 @@
+unique 777820
+word26
+word22
+unique 261150
+unique 944662
+word16
+word8
+  "a": 1,
+{
+unique 427977
+word17
+],
+word21
+{
+unique 5571
+word6
+unique 173913
+unique 303358
+word5
+word6
+word5
+unique 937156
+}
+word3
+unique 276626
+word12
+unique 614613
+word14
+word15
+unique 504097
+unique 193748
+word4
+}
+unique 375016
+unique 18945
+word19
+{
+word4
+unique 206067
+word8
+unique 987804
+word25
+word26
+word22
+word16
+unique 38410
+
+word25
+unique 962684
+word10
+unique 571624
+word13
+
+unique 756164
+unique 507294
+[
+word27
+unique 33372
+word15
+unique 477799
+unique 152703
+},
+unique 350418
+unique 136914
+unique 591446
+unique 661021
+unique 433924
+word20
+[
+unique 309133
+word3
+unique 475571
+unique 722300
+unique 502312
+word23
+word2
+word27
+unique 657420
+unique 909953
+
+word25
+unique 909520
+],
+unique 812539
+},
+unique 381085
+word10
+unique 277724
+unique 724443
+unique 991478
+word4
+{
+word9
+word10
+word25
+unique 836371
+word10
+unique 773668
+unique 428310
+word4
+unique 721773
+word16
+unique 543308
+word3
+word16
+unique 500421
+
+unique 765831
+word26
+unique 441232
+word28
+unique 667803
+}
+word21
+unique 189522
+word4
+unique 43895
+unique 237232
+word1
+word4
+word28
+word22
+],
+word8
+unique 392178
+word18
+unique 897428
+word0
+unique 573825
+},
+word12
+word27
+word11
+unique 858282
+word19
+unique 673496
+word22
+word17
+word5
+unique 638173
+{
+word13
+word27
+word29
+word20
+unique 517378
+word17
+word3
+word9
+unique 315414
+word19
+word12
+word11
+}
+word21
+},
+unique 866968
+word23
+word10
+word23
+unique 257593
+word2
+unique 299608
+word23
+word0
+word1
+word27
+unique 643376
+word26
+word15
+word1
+word9
+  "a": 1,
+unique 572094
+word20
+unique 448509
+word23
+unique 882062
+word18
+unique 754565
+word11
+],
+{
+unique 453844
+word23
+unique 149615
+word28
+unique 346802
+word20
+[
+word7
+
+unique 695836
+unique 349229
+unique 72042
+unique 735311
+word0
+unique 229881
+unique 864393
+unique 747051
+unique 306701
+unique 459245
+word4
+word3
+word6
+  "a": 1,
+unique 781740
+[
+word0
+unique 921129
+unique 10453
+word13
+unique 513218
+word16
+unique 144947
+unique 158449
+word25
+word28
+word7
+word28
+word11
+word1
+unique 905448
+word17
+}
+unique 89384
+},
+edit 401
+word22
+word22
+word13
+word26
+word17
+word2
+unique 450190
+unique 233924
+unique 87846
+unique 697368
+word6
+word17
+word8
+word15
+unique 396507
+word15
+unique 843582
+unique 892942
+},
+word29
+word4
+  "a": 1,
+word4
+},
+word23
+unique 365447
+word23
+word20
+word22
+word2
+unique 74648
+word17
+unique 492474
+word9
+word3
+unique 704628
+word23
+unique 361023
+unique 386891
+[
+unique 706544
+word2
+word13
+word8
+unique 171157
+}
+
+unique 955958
+unique 381947
+unique 193437
+word11
+word22
+word8
+word6
+unique 677247
+unique 761708
+unique 6577
+word21
+unique 430093
+},
+word26
+  "a": 1,
+unique 248507
+unique 515018
+word23
+word8
+unique 521580
+[
+word19
+[
+unique 996289
+unique 188100
+unique 671669
+word27
+word13
+unique 91036
+word3
+word6
+unique 462643
+word24
+word17
+word1
+unique 957348
+unique 722054
+word7
+word1
+
+unique 276279
+unique 328074
+word27
+word8
+unique 837612
+word29
+unique 289231
+word8
+word5
+unique 925535
+  "a": 1,
+word13
+word28
+word11
+unique 796622
+}
+}
+unique 735237
+  "a": 1,
+unique 400981
+word13
+word3
+word24
+unique 299390
+word27
+unique 584292
+word0
+word1
+unique 457132
+unique 995329
+unique 17431
+word20
+word5
+{
+word3
+unique 99926
+word3
+unique 151029
+word20
+unique 123944
+word21
+unique 483390
+word3
+word3
+word24
+  "a": 1,
+word19
+unique 217639
+unique 34467
+unique 247280
+word15
+word6
+word5
+word26
+word8
+word8
+unique 695350
+unique 891115
+word24
+word2
+word8
+},
+word18
+word11
+unique 208999
+unique 791728
+word23
+unique 653371
+word5
+{
+word4
+unique 23296
+}
+unique 538539
+word23
+word18
+word28
+unique 562790
+word19
+}
+unique 547575
+word11
+
+unique 640348
+word19
+{
+word8
+
+unique 315193
+unique 721079
+unique 977469
+unique 640659
+word23
+word0
+word27
+{
+unique 396340
+word26
+unique 954387
+unique 337021
+  "a": 1,
+unique 932480
+word16
+  "a": 1,
+word12
+unique 835896
+unique 940846
+unique 80398
+unique 803720
+],
+word27
+unique 437097
+word23
+word8
+word10
+word14
+}
+word11
+word29
+word5
+  "a": 1,
+unique 723753
+],
+word24
+word26
+unique 333915
+word22
+unique 971729
+unique 936846
+unique 881558
+unique 880709
+word5
+unique 117022
+word2
+  "a": 1,
+word21
+unique 856023
+unique 191926
+unique 968970
+  "a": 1,
+],
+word13
+word22
+unique 779020
+unique 257797
+word27
+{
+word16
+{
+word14
+unique 75701
+word11
+word23
+unique 787412
+unique 963004
+word2
+word24
+unique 803342
+unique 603644
+word19
+],
+[
+word8
+],
+  "a": 1,
+word20
+unique 567656
+unique 897040
+unique 96280
+word1
+unique 5372
+unique 147650
+}
+word28
+word17
+unique 82218
+word6
+word21
+word26
+word10
+word23
+[
+word25
+},
+word19
+unique 433329
+unique 863657
+unique 959819
+unique 15630
+unique 792981
+word14
+word5
+],
+unique 227223
+word5
+word23
+{
+unique 129326
+unique 794863
+],
+unique 37659
+unique 928333
+}
+unique 517823
+unique 628004
+word22
+word16
+unique 32610
+word20
+word1
+unique 391058
+word6
+word26
+unique 935746
+unique 94437
+  "a": 1,
+unique 393204
+word23
+word21
+unique 252618
+unique 147290
+word27
+word17
+word23
+unique 58615
+word6
+word25
+unique 247494
+unique 58782
+unique 250108
+word17
+unique 814240
+word12
+  "a": 1,
+unique 969715
+unique 596338
+word17
+unique 316431
+unique 619577
+unique 81140
+unique 57887
+unique 554424
+unique 103243
+unique 313744
+unique 391221
+unique 144708
+],
+word29
+word29
+[
+unique 122554
+word2
+word14
+word10
+word2
+unique 274839
+}
+unique 407489
+word8
+unique 620775
+unique 459949
+unique 876978
+word1
+word15
+{
+word24
+unique 681147
+word19
+word26
+unique 641776
+word27
+unique 116418
+unique 754140
+unique 97313
+word22
+word26
+unique 755881
+unique 674358
+unique 286635
+word12
+word12
+word13
+word24
+word2
+unique 989226
+word6
+word2
+
+unique 459016
+unique 682228
+word5
+word24
+word25
+word29
+}
+word10
+word25
+word6
+word3
+word9
+unique 307863
+unique 205603
+word15
+unique 541331
+
+unique 122131
+unique 115891
+  "a": 1,
+unique 298390
+unique 610769
+word27
+word14
+unique 273338
+word14
+unique 327081
+word14
+word19
+word12
+},
+unique 828938
+unique 530974
+word22
+word7
+word29
+  "a": 1,
+unique 338999
+unique 991931
+word17
+word8
+word27
+word27
+word0
+word28
+word20
+word25
+unique 140167
+word4
+unique 341505
+unique 746092
+word1
+word22
+word28
+unique 601857
+unique 268224
+word9
+word18
+unique 634245
+word5
+  "a": 1,
+word7
+word3
+unique 754290
+word22
+unique 171097
+unique 505220
+word9
+unique 731618
+word28
+}
+word23
+unique 512448
+unique 546638
+unique 295772
+unique 60681
+unique 139277
+word11
+word1
+word5
+unique 763672
+word24
+unique 327621
+unique 437762
+unique 441249
+unique 620185
+unique 356353
+unique 549683
+{
+word24
+unique 565209
+unique 808982
+word16
+word27
+word0
+unique 177194
+unique 894057
+word18
+unique 403600
+word7
+unique 964787
+word8
+word25
+word12
+  "a": 1,
+word28
+unique 466126
+unique 761893
+word9
+{
+[
+word5
+unique 111464
+word21
+unique 492223
+word26
+word22
+unique 643898
+unique 952455
+unique 647347
+word21
+unique 211104
+unique 730268
+  "a": 1,
+
+word18
+word20
+word5
+unique 407629
+word0
+unique 722705
+word1
+word21
+}
+word26
+unique 727123
+unique 879234
+unique 799789
+word7
+unique 831564
+unique 863984
+unique 690231
+word21
+unique 212457
+word9
+unique 909058
+unique 201411
+unique 881091
+  "a": 1,
+unique 454032
+word2
+unique 190966
+word2
+unique 994198
+{
+unique 489271
+unique 874522
+unique 761706
+unique 83709
+word16
+[
+unique 387604
+unique 500872
+word29
+word14
+unique 563032
+word18
+word21
+unique 17307
+word11
+word25
+word27
+
+unique 623284
+
+unique 427080
+word15
+unique 816584
+word0
+unique 933565
+
+word25
+unique 226974
+word2
+word21
+word26
+word11
+unique 618642
+word17
+  "a": 1,
+word20
+word14
+unique 373495
+unique 484042
+word24
+word21
+unique 975952
+word7
+unique 324538
+word25
+word25
+word25
+word21
+word5
+unique 425184
+unique 130031
+word21
+},
+word16
+  "a": 1,
+word21
+word12
+  "a": 1,
+[
+
+word11
+word7
+word22
+word14
+word25
+word18
+unique 649488
+},
+word23
+unique 890733
+unique 116694
+word10
+word14
+unique 985887
+word18
+unique 277472
+word27
+word10
+word19
+word18
+word5
+unique 458611
+unique 540973
+word11
+word5
+unique 691000
+word24
+unique 700271
+word0
+unique 321310
+word14
+unique 170211
+unique 13246
+word24
+word23
+},
+unique 346143
+unique 231764
+}
+word0
+unique 113937
+unique 326936
+],
+unique 809719
+unique 776148
+word15
+unique 267391
+word10
+word18
+word24
+unique 804386
+word7
+unique 764920
+unique 792637
+unique 584233
+word29
+word24
+word9
+word2
+
+unique 335295
+word24
+{
+word13
+word15
+word6
+unique 829005
+word21
+{
+word5
+{
+
+word6
+word6
+word1
+word3
+word24
+word21
+unique 724375
+word4
+word27
+unique 709119
+unique 363416
+word26
+unique 15051
+word28
+word3
+unique 327368
+word23
+unique 635065
+unique 140283
+word28
+word5
+unique 640314
+unique 21813
+unique 189444
+}
+word3
+word10
+word15
+word0
+unique 21838
+unique 989223
+word17
+word9
+word18
+word2
+word23
+unique 64794
+unique 417237
+unique 46478
+word9
+word4
+word3
+unique 742179
+unique 897844
+unique 241788
+[
+word7
+word23
+word4
+unique 490882
+word24
+word29
+word8
+word7
+unique 88540
+word21
+unique 565935
+unique 12025
+unique 153750
+word1
+unique 841913
+],
+unique 462533
+unique 992042
+}
+word20
+word26
+unique 907282
+word7
+unique 517514
+word28
+word13
+},
+word28
+unique 290840
+unique 241389
+unique 253095
+],
+word23
+word19
+word16
+word13
+word2
+word11
+unique 987697
+unique 802273
+word11
+word28
+word0
+word10
+  "a": 1,
+word12
+unique 569196
+unique 232121
+word4
+word20
+word0
+word26
+unique 773067
+  "a": 1,
+word12
+word1
+unique 952114
+unique 380993
+unique 581229
+unique 291539
+word20
+unique 7688
+word12
+unique 498409
+word26
+unique 256164
+unique 115108
+word8
+unique 532227
+unique 549067
+word23
+word16
+}
+word18
+word22
+word7
+unique 950143
+word6
+word24
+],
+word28
+word20
+word22
+unique 696629
+word7
+word2
+word8
+unique 812657
+word4
+word24
+word1
+word3
+unique 506169
+word22
+word22
+word16
+unique 979921
+unique 255920
+word10
+unique 389195
+unique 713971
+word15
+word3
+unique 399544
+word16
+unique 441418
+word7
+}
+},
+word5
+unique 448318
+unique 644674
+unique 704384
+unique 448769
+unique 387803
+word0
+word17
+unique 996319
+word3
+word10
+unique 325930
+word3
+},
+unique 344032
+word24
+word12
+word2
+unique 527610
+unique 801721
+unique 496247
+word18
+word10
+unique 651909
+unique 27276
+unique 746474
+  "a": 1,
+word3
+word29
+word9
+unique 888764
+word28
+word20
+unique 933379
+unique 735016
+word4
+unique 675285
+word6
+unique 744471
+word23
+word18
+unique 337852
+unique 329774
+word11
+unique 494148
+unique 813407
+unique 274826
+unique 836306
+  "a": 1,
+  "a": 1,
+unique 140628
+word19
+word16
+word29
+word26
+unique 659915
+word29
+unique 520494
+],
+word1
+unique 623377
+unique 800958
+word0
+],
+word21
+unique 286599
+unique 104707
+word6
+word7
+word20
+word24
+word7
+word24
+unique 953747
+unique 885135
+word1
+unique 427726
+unique 140523
+unique 405658
+unique 435730
+word15
+word22
+unique 514019
+word7
+word15
+word20
+}
+unique 775520
+unique 921751
+word27
+word1
+word19
+unique 355638
+],
+],
+word25
+word5
+unique 965364
+word15
+word16
+word5
+word12
+word19
+unique 621704
+word13
+unique 619462
+unique 12519
+word5
+{
+unique 822852
+word24
+word21
+word13
+word26
+word29
+}
+  "a": 1,
+unique 496764
+unique 874660
+unique 563239
+unique 638537
+}
+unique 394473
+},
+unique 744532
+word13
+word16
+unique 593372
+word6
+word5
+unique 185361
+unique 130736
+unique 405661
+unique 16523
+unique 286481
+unique 503540
+word7
+word20
+unique 163757
+word9
+word27
+word13
+word5
+word27
+unique 729344
+word20
+unique 513758
+word14
+word26
+word9
+unique 119914
+unique 78571
+word1
+word16
+word21
+unique 974780
+unique 690946
+unique 494750
+unique 245172
+word27
+word16
+unique 211349
+unique 449783
+unique 47638
+word19
+word0
+{
+unique 769223
+unique 999363
+word25
+unique 632098
+word14
+unique 482575
+unique 407246
+unique 578556
+word12
+unique 613382
+word6
+unique 266695
+unique 45680
+unique 380114
+word16
+word9
+word9
+unique 271684
+
+word3
+],
+word28
+unique 920412
+word10
+unique 123786
+word18
+unique 464094
+
+word29
+word7
+word10
+word22
+word17
+unique 291690
+unique 83319
+word3
+unique 857586
+word17
+word28
+}
+word24
+}
+],
+unique 345031
+word21
+word12
+  "a": 1,
+word15
+unique 390891
+unique 156264
+word9
+word8
+word2
+unique 187036
+word24
+unique 751155
+word4
+word26
+word2
+word16
+{
+unique 982188
+word18
+unique 625728
+unique 811378
+unique 138046
+unique 266967
+unique 160355
+unique 752115
+unique 968545
+word3
+word6
+unique 750088
+unique 201888
+unique 971389
+word22
+word2
+unique 289713
+unique 980712
+unique 93238
+word9
+word22
+word23
+word24
+word22
+word5
+word8
+word1
+word10
+],
+word28
+word22
+word28
+unique 661442
+unique 515459
+word20
+word4
+word13
+word7
+unique 210579
+],
+unique 342683
+unique 452435
+word11
+edit 627
+unique 731214
+word16
+unique 645305
+word5
+unique 394162
+word17
+word12
+unique 13166
+word1
+word14
+word8
+word18
+unique 256157
+word24
+unique 552466
+word18
+unique 850333
+word25
+},
+unique 288235
+unique 615757
+word20
+}
+unique 789196
+unique 883988
+word16
+},
+word12
+unique 85431
+unique 180227
+
+word14
+word20
+word10
+word16
+},
+unique 291464
+unique 102293
+word17
+unique 532243
+}
+unique 152570
+
+word1
+unique 799485
+unique 317469
+unique 983260
+unique 308502
+word4
+word1
+unique 362539
+word5
+unique 738931
+word29
+word29
+word24
+word1
+word2
+unique 809441
+unique 861043
+unique 558025
+word13
+word20
+},
+unique 190991
+unique 656434
+word22
+word10
+unique 512075
+[
+unique 760653
+word7
+word24
+unique 900007
+word7
+word14
+unique 424372
+word15
+word3
+unique 372091
+unique 414367
+word8
+unique 820651
+unique 556408
+{
+word8
+],
+unique 149262
+unique 716729
+unique 223428
+unique 911055
+word20
+word20
+unique 485712
+word21
+unique 360307
+word10
+word12
+  "a": 1,
+word28
+unique 307566
+
+word27
+unique 494074
+unique 225901
+word20
+word25
+word14
+unique 21874
+[
+word9
+word0
+word25
+word27
+unique 30445
+word4
+unique 371532
+word28
+word15
+word20
+unique 651780
+unique 536834
+word13
+word19
+unique 722162
+word11
+unique 531720
+unique 799636
+unique 283621
+unique 846224
+word24
+unique 316061
+word25
+word22
+word25
+unique 828213
+word10
+word25
+word11
+word5
+unique 690548
+{
+word10
+word18
+unique 845259
+word12
+word2
+unique 454718
+unique 198849
+word1
+word18
+word8
+},
+}
+word28
+word18
+unique 346924
+unique 599031
+}
+word23
+word16
+unique 349030
+unique 854944
+unique 229665
+word27
+word26
+unique 552599
+unique 496766
+unique 52736
+unique 544297
+unique 767517
+word8
+word0
+},
+
+word0
+word8
+unique 198550
+word4
+word17
+unique 571857
+unique 448668
+unique 481120
+word29
+],
+unique 449452
+unique 646336
+unique 758373
+
+word11
+word26
+word29
+word23
+unique 67705
+word27
+unique 349242
+word29
+}
+unique 86071
+}
+unique 111821
+word9
+unique 907531
+word14
+unique 272130
+word23
+word1
+word11
+word27
+}
+unique 597905
+word16
+word10
+word10
+unique 797114
+word18
+unique 189912
+word22
+unique 747285
+word17
+unique 90910
+],
+unique 40707
+unique 55551
+word16
+unique 533633
+
+word11
+word7
+unique 694557
+word19
+word14
+unique 37559
+
+}
+unique 139422
+unique 142148
+unique 174836
+unique 467409
+word6
+word22
+],
+unique 581548
+},
+word2
+unique 900349
+word3
+word11
+unique 217213
+word8
+word20
+word27
+word22
+unique 356105
+unique 765899
+word26
+word5
+word26
+word12
+word7
+word18
+unique 325483
+}
+],
+unique 572165
+unique 578351
+[
+unique 428767
+unique 960060
+unique 581337
+unique 843202
+word25
+word3
+unique 780458
+word4
+word10
+word22
+unique 652661
+word23
+word10
+unique 563072
+word8
+word5
+word3
+unique 275386
+unique 835640
+word29
+word23
+word16
+word9
+word2
+[
+word0
+},
+word19
+unique 849882
+word20
+word24
+word4
+unique 253686
+unique 238713
+unique 813684
+word12
+unique 50951
+word19
+unique 17007
+unique 154697
+word13
+word15
+unique 591275
+word11
+},
+{
+word0
+word27
+word8
+word14
+word4
+unique 990916
+unique 358247
+word24
+word28
+unique 985449
+unique 973439
+unique 181175
+word18
+word28
+unique 464661
+word14
+unique 189799
+word7
+word6
+unique 147650
+unique 504723
+[
+},
+  "a": 1,
+unique 821383
+unique 303087
+unique 488792
+],
+word13
+word11
+word21
+unique 859670
+word29
+unique 198423
+word0
+unique 547400
+word28
+unique 843757
+
+unique 916916
+word10
+word1
+unique 11276
+word25
+unique 860332
+unique 814527
+word13
+word14
+unique 502038
+word6
+unique 330262
+unique 547306
+unique 21341
+
+word19
+unique 10651
+word1
+word18
+unique 672884
+{
+unique 52684
+word10
+unique 114912
+unique 721396
+unique 594801
+unique 700582
+unique 415433
+unique 579625
+unique 913978
+word27
+  "a": 1,
+unique 479172
+word5
+word4
+word23
+}
+word27
+unique 263269
+word6
+unique 849618
+{
+],
+word6
+word0
+word20
+unique 248318
+word25
+word24
+word19
+unique 422835
+[
+word7
+unique 945974
+word21
+word15
+word27
+unique 715223
+unique 40339
+word15
+unique 269270
+{
+word23
+unique 625244
+word23
+unique 864739
+unique 970697
+word25
+word11
+word26
+unique 182655
+unique 921054
+{
+unique 524806
+word9
+word9
+unique 827164
+unique 188446
+word14
+word19
+],
+unique 891530
+unique 525590
+word15
+],
+word9
+],
+unique 498116
+unique 330669
+word24
+unique 835172
+unique 775807
+word16
+unique 368786
+unique 57074
+
+word12
+word9
+unique 673345
+word12
+unique 767622
+unique 916775
+word26
+unique 993166
+
+word21
+word8
+word8
+unique 529384
+},
+word5
+[
+unique 821247
+word8
+word27
+unique 825791
+word0
+word29
+word5
+unique 633671
+unique 414907
+word9
+word12
+word28
+unique 608405
+word5
+word29
+word20
+unique 530779
+word10
+{
+unique 147751
+unique 311677
+unique 382014
+word27
+word20
+word8
+},
+word0
+word22
+word16
+unique 631648
+word25
+word10
+word14
+unique 905772
+word29
+word21
+unique 636746
+unique 998564
+word10
+word4
+{
+word11
+word17
+unique 289589
+word13
+word16
+  "a": 1,
+}
+word0
+unique 297720
+word1
+word6
+word18
+word15
+word0
+unique 971417
+unique 554382
+unique 918649
+unique 231149
+unique 347050
+word10
+unique 125166
+word14
+unique 657698
+word6
+unique 397338
+unique 217307
+word24
+word21
+word18
+word29
+word4
+word27
+unique 905784
+unique 597374
+unique 979876
+word20
+unique 265042
+word29
+word3
+word1
+word13
+word21
+unique 611285
+unique 392329
+],
+word3
+word18
+word2
+unique 381117
+{
+word1
+word6
+unique 935318
+
+unique 164789
+word27
+}
+unique 991927
+word23
+word17
+word0
+word0
+unique 185087
+unique 779846
+word18
+word5
+word16
+unique 784070
+word1
+word4
+unique 259563
+
+word28
+{
+unique 771555
+unique 316129
+{
+unique 181073
+unique 32401
+word3
+word16
+word19
+unique 791561
+unique 153441
+unique 736065
+word29
+word2
+word17
+unique 523957
+
+unique 110908
+word24
+unique 861705
+[
+unique 294454
+unique 864993
+word7
+word9
+unique 358329
+word8
+{
+word29
+unique 665946
+unique 49244
+word14
+unique 887578
+word3
+unique 877908
+word1
+word14
+unique 525854
+word2
+word27
+unique 191367
+unique 580969
+word25
+word6
+unique 249924
+unique 26239
+unique 495966
+unique 120582
+],
+unique 824925
+unique 21285
+word8
+word17
+word4
+word5
+
+unique 301788
+unique 549808
+unique 977718
+unique 891737
+word1
+word14
+word27
+unique 694657
+[
+unique 979556
+word23
+  "a": 1,
+}
+
+unique 652104
+word1
+unique 769682
+unique 590154
+unique 992286
+unique 94989
+unique 174767
+word20
+unique 363075
+unique 711499
+word0
+  "a": 1,
+word8
+word16
+unique 461073
+unique 517691
+unique 118411
+word9
+unique 130741
+unique 800446
+unique 102088
+word3
+unique 581444
+word0
+word27
+word22
+unique 76142
+unique 38163
+word4
+word19
+word14
+word7
+unique 413722
+word5
+unique 658981
+
@@ -0,0 +1,17 @@
+{
+  "permissions": {
+    "allow": [
+      "Bash(git diff:*)",
+      "Bash(git status)",
+      "Bash(go test:*)",
+      "Bash(npm run test:*)",
+      "Edit(src/**)",
+      "Read(~/.zshrc)",
+      "WebFetch(domain:github.com)",
+      "WebSearch"
+    ],
+    "deny": [
+      "Bash(rm:*)"
+    ]
+  }
+}
@@ -0,0 +1,14 @@
+{
+  "permissions": {
+    "allow": [
+      "Bash(git status)",
+      "Bash(git diff:*)",
+      "Bash(npm run test:*)",
+      "Read(~/.zshrc)",
+      "WebFetch(domain:github.com)",
+      "WebSearch"
+    ],
+    "deny": [],
+    "defaultMode": "acceptEdits"
+  }
+}
@@ -2,199 +2,609 @@ package hoist
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
 // UnifiedDiff returns a unified diff between two strings, labeled with the given names.
+// Hunks are the same as those GNU diff -u prints for the same input.
 func UnifiedDiff(aName, bName, a, b string) string {
-	if a == b {
-		return ""
-	}
-
-	aLines := splitLines(a)
-	bLines := splitLines(b)
-
-	// Simple Myers-like LCS diff
-	ops := diffLines(aLines, bLines)
-
-	var buf strings.Builder
-	fmt.Fprintf(&buf, "--- %s\n", aName)
-	fmt.Fprintf(&buf, "+++ %s\n", bName)
+	return RenderDiff(aName, bName, a, b, DiffStyle{})
+}
 
-	// Group changes into hunks
-	hunks := buildHunks(ops, 3)
-	for _, h := range hunks {
-		fmt.Fprintf(&buf, "@@ -%d,%d +%d,%d @@\n", h.aStart+1, h.aCount, h.bStart+1, h.bCount)
-		for _, line := range h.lines {
-			buf.WriteString(line)
-			buf.WriteByte('\n')
-		}
+// hunkRange formats a hunk's line range the way GNU diff does: the count is
+// left out when it is 1, and an empty range names the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
 	}
-
-	return buf.String()
+	return fmt.Sprintf("%d,%d", start+1, count)
 }
 
 type diffOp struct {
-	kind byte // ' ', '+', '-'
-	line string
-	aIdx int // line index in a (-1 if added)
-	bIdx int // line index in b (-1 if removed)
+	kind      byte   // ' ', '+', '-'
+	line      string // without its newline
+	aIdx      int    // line index in a (-1 if added)
+	bIdx      int    // line index in b (-1 if removed)
+	noNewline bool   // the line ends the file without a newline
 }
 
+// splitLines splits s into lines, each keeping its trailing newline so a
+// missing newline at the end of the file counts as a difference.
 func splitLines(s string) []string {
 	if s == "" {
 		return nil
 	}
-	lines := strings.Split(s, "\n")
-	// Remove trailing empty line from trailing newline
-	if len(lines) > 0 && lines[len(lines)-1] == "" {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
 	}
 	return lines
 }
 
-// diffLines produces a sequence of diff operations using LCS.
+// diffLines produces the sequence of diff operations turning a into b.
+// Within each change, removed lines come before added ones.
 func diffLines(a, b []string) []diffOp {
-	n, m := len(a), len(b)
-
-	// Build LCS table
-	lcs := make([][]int, n+1)
-	for i := range lcs {
-		lcs[i] = make([]int, m+1)
-	}
-	for i := n - 1; i >= 0; i-- {
-		for j := m - 1; j >= 0; j-- {
-			if a[i] == b[j] {
-				lcs[i][j] = lcs[i+1][j+1] + 1
-			} else if lcs[i+1][j] >= lcs[i][j+1] {
-				lcs[i][j] = lcs[i+1][j]
-			} else {
-				lcs[i][j] = lcs[i][j+1]
-			}
-		}
+	delA, insB := changedLines(a, b)
+
+	op := func(kind byte, line string, ai, bi int) diffOp {
+		text, ok := strings.CutSuffix(line, "\n")
+		return diffOp{kind: kind, line: text, aIdx: ai, bIdx: bi, noNewline: !ok}
 	}
 
-	// Walk the table to produce ops
 	var ops []diffOp
 	i, j := 0, 0
-	for i < n && j < m {
-		if a[i] == b[j] {
-			ops = append(ops, diffOp{' ', a[i], i, j})
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && delA[i]:
+			ops = append(ops, op('-', a[i], i, -1))
 			i++
+		case j < len(b) && insB[j]:
+			ops = append(ops, op('+', b[j], -1, j))
 			j++
-		} else if lcs[i+1][j] >= lcs[i][j+1] {
-			ops = append(ops, diffOp{'-', a[i], i, -1})
+		default:
+			ops = append(ops, op(' ', a[i], i, j))
 			i++
-		} else {
-			ops = append(ops, diffOp{'+', b[j], -1, j})
 			j++
 		}
 	}
-	for ; i < n; i++ {
-		ops = append(ops, diffOp{'-', a[i], i, -1})
+	return ops
+}
+
+// diffHorizon is how many identical lines next to a difference take part in
+// the comparison, so changes can still slide into them. GNU diff uses the
+// context size.
+const diffHorizon = 3
+
+// changedLines reports which lines of a are deleted and which lines of b are
+// inserted. It follows GNU diff: identical ends are set aside, lines with no
+// match in the other file are discarded up front, the rest is compared with
+// Myers' O(ND) algorithm in linear space, and changes are finally slid to
+// where they read best.
+func changedLines(a, b []string) (delA, insB []bool) {
+	ids := make(map[string]int)
+	equivs := func(lines []string) []int {
+		e := make([]int, len(lines))
+		for i, l := range lines {
+			id, ok := ids[l]
+			if !ok {
+				id = len(ids) + 1 // class 0 is never used, as in GNU diff
+				ids[l] = id
+			}
+			e[i] = id
+		}
+		return e
+	}
+	ea, eb := equivs(a), equivs(b)
+	n, m := len(ea), len(eb)
+
+	// Set aside the identical prefix and suffix, keeping diffHorizon lines of each.
+	p := 0
+	for p < n && p < m && ea[p] == eb[p] {
+		p++
 	}
-	for ; j < m; j++ {
-		ops = append(ops, diffOp{'+', b[j], -1, j})
+	lo := p - min(p, diffHorizon)
+	s := 0
+	for n-1-s >= lo && m-1-s >= lo && ea[n-1-s] == eb[m-1-s] {
+		s++
 	}
-	return ops
+	cut := s - min(s, diffHorizon)
+
+	fa := &diffFile{equivs: ea[lo : n-cut]}
+	fb := &diffFile{equivs: eb[lo : m-cut]}
+	discardConfusingLines(fa, fb, len(ids)+1)
+
+	c := newDiffContext(fa, fb)
+	c.compareSeq(0, len(fa.undiscarded), 0, len(fb.undiscarded), false)
+
+	shiftBoundaries(fa, fb)
+	shiftBoundaries(fb, fa)
+
+	delA = make([]bool, n)
+	insB = make([]bool, m)
+	copy(delA[lo:], fa.changed[1:len(fa.equivs)+1])
+	copy(insB[lo:], fb.changed[1:len(fb.equivs)+1])
+	return delA, insB
 }
 
-type hunk struct {
-	aStart, aCount int
-	bStart, bCount int
-	lines          []string
+// diffFile is one side of a comparison.
+type diffFile struct {
+	equivs      []int  // equivalence class of each line
+	changed     []bool // changed[i+1] is set when line i changed; both ends are always false
+	undiscarded []int  // equivalence classes of the lines left after discarding
+	realIndex   []int  // line index of each undiscarded line
 }
 
-func buildHunks(ops []diffOp, context int) []hunk {
-	// Find ranges of changes, expand by context, merge overlapping
-	type changeRange struct{ start, end int } // indices into ops
-	var changes []changeRange
-	for i, op := range ops {
-		if op.kind != ' ' {
-			if len(changes) > 0 && changes[len(changes)-1].end >= i-1 {
-				changes[len(changes)-1].end = i
+// discardConfusingLines marks lines that match nothing in the other file as
+// changed, and also lines that match many others when they sit in a run of
+// such lines, and leaves the rest for the main comparison.
+func discardConfusingLines(fa, fb *diffFile, classes int) {
+	files := [2]*diffFile{fa, fb}
+	var counts [2][]int
+	for f, file := range files {
+		counts[f] = make([]int, classes)
+		for _, e := range file.equivs {
+			counts[f][e]++
+		}
+	}
+
+	for f, file := range files {
+		end := len(file.equivs)
+		discards := make([]byte, end)
+		other := counts[1-f]
+
+		// Lines matching more than about the square root of the file
+		// size are provisionally discardable.
+		many := 5
+		for tem := end / 64; ; {
+			if tem >>= 2; tem <= 0 {
+				break
+			}
+			many *= 2
+		}
+		for i, e := range file.equivs {
+			switch nmatch := other[e]; {
+			case nmatch == 0:
+				discards[i] = 1
+			case nmatch > many:
+				discards[i] = 2
+			}
+		}
+
+		// Keep provisional discards only inside a run of discardable lines
+		// that starts and ends with a line that matches nothing.
+		for i := 0; i < end; i++ {
+			if discards[i] == 2 {
+				discards[i] = 0
+				continue
+			}
+			if discards[i] == 0 {
+				continue
+			}
+
+			j, provisional := i, 0
+			for ; j < end && discards[j] != 0; j++ {
+				if discards[j] == 2 {
+					provisional++
+				}
+			}
+			for j > i && discards[j-1] == 2 {
+				j--
+				discards[j] = 0
+				provisional--
+			}
+			length := j - i
+
+			if provisional*4 > length {
+				for ; j > i; j-- {
+					if discards[j-1] == 2 {
+						discards[j-1] = 0
+					}
+				}
+				continue
+			}
+
+			// Cancel subruns of at least minimum provisionals, where
+			// minimum is about the square root of length/4.
+			minimum := 1
+			for tem := length >> 2; ; {
+				if tem >>= 2; tem <= 0 {
+					break
+				}
+				minimum <<= 1
+			}
+			minimum++
+			for j, consec := 0, 0; j < length; j++ {
+				if discards[i+j] != 2 {
+					consec = 0
+					continue
+				}
+				consec++
+				if consec == minimum {
+					j -= consec // back up to cancel the whole subrun
+				} else if minimum < consec {
+					discards[i+j] = 0
+				}
+			}
+
+			// Cancel provisionals at either end until three lines that
+			// match nothing are found in a row, or one at least 8 lines in.
+			for j, consec := 0, 0; j < length; j++ {
+				if j >= 8 && discards[i+j] == 1 {
+					break
+				}
+				switch discards[i+j] {
+				case 2:
+					consec = 0
+					discards[i+j] = 0
+				case 0:
+					consec = 0
+				default:
+					consec++
+				}
+				if consec == 3 {
+					break
+				}
+			}
+			i += length - 1
+			for j, consec := 0, 0; j < length; j++ {
+				if j >= 8 && discards[i-j] == 1 {
+					break
+				}
+				switch discards[i-j] {
+				case 2:
+					consec = 0
+					discards[i-j] = 0
+				case 0:
+					consec = 0
+				default:
+					consec++
+				}
+				if consec == 3 {
+					break
+				}
+			}
+		}
+
+		file.changed = make([]bool, end+2)
+		for i, e := range file.equivs {
+			if discards[i] == 0 {
+				file.undiscarded = append(file.undiscarded, e)
+				file.realIndex = append(file.realIndex, i)
 			} else {
-				changes = append(changes, changeRange{i, i})
+				file.changed[i+1] = true
 			}
 		}
 	}
+}
+
+// diffContext holds the state of the middle-snake search. fd and bd are the
+// furthest-reaching forward and backward paths, indexed by diagonal plus off.
+type diffContext struct {
+	x, y         *diffFile
+	fd, bd       []int
+	off          int
+	tooExpensive int
+}
+
+func newDiffContext(x, y *diffFile) *diffContext {
+	diags := len(x.undiscarded) + len(y.undiscarded) + 3
+	c := &diffContext{
+		x:   x,
+		y:   y,
+		fd:  make([]int, diags),
+		bd:  make([]int, diags),
+		off: len(y.undiscarded) + 1,
+	}
 
-	if len(changes) == 0 {
-		return nil
+	// Give up on a minimal diff once the edit cost passes roughly the
+	// square root of the input size, but not below 4096.
+	c.tooExpensive = 1
+	for ; diags != 0; diags >>= 2 {
+		c.tooExpensive <<= 1
 	}
+	c.tooExpensive = max(4096, c.tooExpensive)
+	return c
+}
+
+// compareSeq marks the changes between x[xoff:xlim] and y[yoff:ylim],
+// splitting the problem at the middle snake of a shortest edit script.
+func (c *diffContext) compareSeq(xoff, xlim, yoff, ylim int, findMinimal bool) {
+	xv, yv := c.x.undiscarded, c.y.undiscarded
+
+	for xoff < xlim && yoff < ylim && xv[xoff] == yv[yoff] {
+		xoff++
+		yoff++
+	}
+	for xoff < xlim && yoff < ylim && xv[xlim-1] == yv[ylim-1] {
+		xlim--
+		ylim--
+	}
+
+	switch {
+	case xoff == xlim:
+		for ; yoff < ylim; yoff++ {
+			c.y.changed[c.y.realIndex[yoff]+1] = true
+		}
+	case yoff == ylim:
+		for ; xoff < xlim; xoff++ {
+			c.x.changed[c.x.realIndex[xoff]+1] = true
+		}
+	default:
+		part := c.diag(xoff, xlim, yoff, ylim, findMinimal)
+		c.compareSeq(xoff, part.xmid, yoff, part.ymid, part.loMinimal)
+		c.compareSeq(part.xmid, xlim, part.ymid, ylim, part.hiMinimal)
+	}
+}
 
-	// Expand each change range by context and merge overlapping
-	type expandedRange struct{ start, end int }
-	var expanded []expandedRange
-	for _, c := range changes {
-		s := c.start - context
-		if s < 0 {
-			s = 0
+type partition struct {
+	xmid, ymid           int
+	loMinimal, hiMinimal bool
+}
+
+// diag finds the midpoint of a shortest edit script for x[xoff:xlim] and
+// y[yoff:ylim] by searching forward from the start and backward from the
+// end until the two searches overlap.
+func (c *diffContext) diag(xoff, xlim, yoff, ylim int, findMinimal bool) partition {
+	fd, bd, off := c.fd, c.bd, c.off
+	xv, yv := c.x.undiscarded, c.y.undiscarded
+
+	dmin, dmax := xoff-ylim, xlim-yoff
+	fmid, bmid := xoff-yoff, xlim-ylim
+	fmin, fmax := fmid, fmid
+	bmin, bmax := bmid, bmid
+	odd := (fmid-bmid)&1 != 0
+
+	fd[off+fmid] = xoff
+	bd[off+bmid] = xlim
+
+	for cost := 1; ; cost++ {
+		// Extend the forward search by an edit step in each diagonal.
+		if fmin > dmin {
+			fmin--
+			fd[off+fmin-1] = -1
+		} else {
+			fmin++
 		}
-		e := c.end + context
-		if e >= len(ops) {
-			e = len(ops) - 1
+		if fmax < dmax {
+			fmax++
+			fd[off+fmax+1] = -1
+		} else {
+			fmax--
+		}
+		for d := fmax; d >= fmin; d -= 2 {
+			tlo, thi := fd[off+d-1], fd[off+d+1]
+			x0 := thi
+			if tlo >= thi {
+				x0 = tlo + 1
+			}
+			x, y := x0, x0-d
+			for x < xlim && y < ylim && xv[x] == yv[y] {
+				x++
+				y++
+			}
+			fd[off+d] = x
+			if odd && bmin <= d && d <= bmax && bd[off+d] <= x {
+				return partition{x, y, true, true}
+			}
 		}
-		if len(expanded) > 0 && expanded[len(expanded)-1].end >= s-1 {
-			expanded[len(expanded)-1].end = e
+
+		// And the backward search.
+		if bmin > dmin {
+			bmin--
+			bd[off+bmin-1] = math.MaxInt
+		} else {
+			bmin++
+		}
+		if bmax < dmax {
+			bmax++
+			bd[off+bmax+1] = math.MaxInt
 		} else {
-			expanded = append(expanded, expandedRange{s, e})
+			bmax--
+		}
+		for d := bmax; d >= bmin; d -= 2 {
+			tlo, thi := bd[off+d-1], bd[off+d+1]
+			x0 := tlo
+			if tlo >= thi {
+				x0 = thi - 1
+			}
+			x, y := x0, x0-d
+			for xoff < x && yoff < y && xv[x-1] == yv[y-1] {
+				x--
+				y--
+			}
+			bd[off+d] = x
+			if !odd && fmin <= d && d <= fmax && x <= fd[off+d] {
+				return partition{x, y, true, true}
+			}
 		}
+
+		if findMinimal || cost < c.tooExpensive {
+			continue
+		}
+
+		// Too expensive: split at whichever search got furthest.
+		fxybest, fxbest := -1, 0
+		for d := fmax; d >= fmin; d -= 2 {
+			x := min(fd[off+d], xlim)
+			y := x - d
+			if ylim < y {
+				x, y = ylim+d, ylim
+			}
+			if fxybest < x+y {
+				fxybest, fxbest = x+y, x
+			}
+		}
+		bxybest, bxbest := math.MaxInt, 0
+		for d := bmax; d >= bmin; d -= 2 {
+			x := max(xoff, bd[off+d])
+			y := x - d
+			if y < yoff {
+				x, y = yoff+d, yoff
+			}
+			if x+y < bxybest {
+				bxybest, bxbest = x+y, x
+			}
+		}
+		if (xlim+ylim)-bxybest < fxybest-(xoff+yoff) {
+			return partition{fxbest, fxybest - fxbest, true, false}
+		}
+		return partition{bxbest, bxybest - bxbest, false, true}
 	}
+}
 
-	var hunks []hunk
-	for _, r := range expanded {
-		var h hunk
-		h.aStart = -1
-		h.bStart = -1
-		for i := r.start; i <= r.end; i++ {
-			op := ops[i]
-			h.lines = append(h.lines, string(op.kind)+op.line)
-			switch op.kind {
-			case ' ':
-				if h.aStart == -1 {
-					h.aStart = op.aIdx
-				}
-				if h.bStart == -1 {
-					h.bStart = op.bIdx
+// shiftBoundaries slides each run of changes in f up to merge it with
+// earlier runs, then down as far as it goes, and finally back up to line up
+// with a run of changes in the other file if one is adjacent.
+func shiftBoundaries(f, other *diffFile) {
+	equivs := f.equivs
+	changed := f.changed[1:] // changed[-1] and changed[len] are the false ends
+	oc := other.changed
+	iEnd := len(equivs)
+	i, j := 0, 0 // j indexes oc, shifted by one like f.changed
+
+	isChanged := func(k int) bool { return f.changed[k+1] }
+	otherChanged := func(k int) bool { return oc[k+1] }
+
+	for {
+		// Find the start of the next run, tracking the matching point in other.
+		for i < iEnd && !changed[i] {
+			for {
+				c := otherChanged(j)
+				j++
+				if !c {
+					break
 				}
-				h.aCount++
-				h.bCount++
-			case '-':
-				if h.aStart == -1 {
-					h.aStart = op.aIdx
-				}
-				if h.bStart == -1 {
-					// Find next context or add line to figure out bStart
-					for j := i + 1; j <= r.end; j++ {
-						if ops[j].bIdx >= 0 {
-							h.bStart = ops[j].bIdx
-							break
-						}
-					}
-					if h.bStart == -1 {
-						h.bStart = 0
-					}
+			}
+			i++
+		}
+		if i == iEnd {
+			break
+		}
+		start := i
+
+		for {
+			i++
+			if !isChanged(i) {
+				break
+			}
+		}
+		for otherChanged(j) {
+			j++
+		}
+
+		var corresponding int
+		for {
+			runlength := i - start
+
+			// Move the run back while the line before it matches its
+			// last line, merging it with earlier runs.
+			for start > 0 && equivs[start-1] == equivs[i-1] {
+				start--
+				changed[start] = true
+				i--
+				changed[i] = false
+				for isChanged(start - 1) {
+					start--
 				}
-				h.aCount++
-			case '+':
-				if h.bStart == -1 {
-					h.bStart = op.bIdx
-				}
-				if h.aStart == -1 {
-					for j := i + 1; j <= r.end; j++ {
-						if ops[j].aIdx >= 0 {
-							h.aStart = ops[j].aIdx
-							break
-						}
+				for {
+					j--
+					if !otherChanged(j) {
+						break
 					}
-					if h.aStart == -1 {
-						h.aStart = 0
+				}
+			}
+
+			corresponding = iEnd
+			if otherChanged(j - 1) {
+				corresponding = i
+			}
+
+			// Move it forward while its first line matches the line
+			// after it, merging it with later runs.
+			for i != iEnd && equivs[start] == equivs[i] {
+				changed[start] = false
+				start++
+				changed[i] = true
+				i++
+				for isChanged(i) {
+					i++
+				}
+				for {
+					j++
+					if !otherChanged(j) {
+						break
 					}
+					corresponding = i
+				}
+			}
+
+			if runlength == i-start {
+				break
+			}
+		}
+
+		// Move the merged run back to line up with changes in other.
+		for corresponding < i {
+			start--
+			changed[start] = true
+			i--
+			changed[i] = false
+			for {
+				j--
+				if !otherChanged(j) {
+					break
 				}
+			}
+		}
+	}
+}
+
+type hunk struct {
+	aStart, aCount int
+	bStart, bCount int
+	ops            []diffOp
+}
+
+// buildHunks groups ops into hunks with the given number of context lines.
+// Changes separated by no more than twice that many lines share a hunk.
+func buildHunks(ops []diffOp, context int) []hunk {
+	type opRange struct{ start, end int } // indices into ops, inclusive
+	var ranges []opRange
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		s, e := max(0, i-context), min(len(ops)-1, i+context)
+		if n := len(ranges); n > 0 && ranges[n-1].end >= s-1 {
+			ranges[n-1].end = e
+		} else {
+			ranges = append(ranges, opRange{s, e})
+		}
+	}
+
+	var hunks []hunk
+	aPos, bPos, next := 0, 0, 0
+	for _, r := range ranges {
+		for ; next < r.start; next++ {
+			aPos++
+			bPos++
+		}
+		h := hunk{aStart: aPos, bStart: bPos, ops: ops[r.start : r.end+1]}
+		for _, op := range h.ops {
+			if op.kind != '+' {
+				h.aCount++
+			}
+			if op.kind != '-' {
 				h.bCount++
 			}
 		}
+		aPos += h.aCount
+		bPos += h.bCount
+		next = r.end + 1
 		hunks = append(hunks, h)
 	}
 	return hunks
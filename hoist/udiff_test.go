@@ -1,6 +1,9 @@
 package hoist
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -78,3 +81,116 @@ func TestSplitLines(t *testing.T) {
 		}
 	}
 }
+
+// TestUnifiedDiffMatchesGNU compares hunks against the output of GNU diff -u
+// for each testdata/udiff/NAME.a and NAME.b pair, stored in NAME.diff
+// without the two header lines.
+func TestUnifiedDiffMatchesGNU(t *testing.T) {
+	pairs, err := filepath.Glob("testdata/udiff/*.a")
+	if err != nil || len(pairs) == 0 {
+		t.Fatalf("no fixtures: %v", err)
+	}
+	for _, aPath := range pairs {
+		name := strings.TrimSuffix(aPath, ".a")
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			a, _ := os.ReadFile(name + ".a")
+			b, _ := os.ReadFile(name + ".b")
+			want, err := os.ReadFile(name + ".diff")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := UnifiedDiff("a", "b", string(a), string(b))
+			got = strings.TrimPrefix(got, "--- a\n+++ b\n")
+			if got != string(want) {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+// benchInput returns two n-line settings-like files that differ in about
+// one line in fifty.
+func benchInput(n int) (a, b []string) {
+	for i := 0; i < n; i++ {
+		line := fmt.Sprintf("    \"Bash(tool-%d:*)\",\n", i)
+		a = append(a, line)
+		switch i % 50 {
+		case 7:
+			b = append(b, fmt.Sprintf("    \"Read(dir-%d/**)\",\n", i))
+		case 23:
+			// removed
+		default:
+			b = append(b, line)
+		}
+	}
+	return a, b
+}
+
+func BenchmarkDiffLines(b *testing.B) {
+	for _, n := range []int{1000, 5000, 20000} {
+		x, y := benchInput(n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				diffLines(x, y)
+			}
+		})
+	}
+}
+
+// BenchmarkDiffLinesLCS measures the quadratic LCS table diffLines used to
+// build, for comparison. It is too slow and memory hungry for 20000 lines.
+func BenchmarkDiffLinesLCS(b *testing.B) {
+	for _, n := range []int{1000, 5000} {
+		x, y := benchInput(n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				lcsDiffLines(x, y)
+			}
+		})
+	}
+}
+
+// lcsDiffLines is the previous diffLines implementation, kept for benchmarks.
+func lcsDiffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		if a[i] == b[j] {
+			ops = append(ops, diffOp{kind: ' ', line: a[i], aIdx: i, bIdx: j})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			ops = append(ops, diffOp{kind: '-', line: a[i], aIdx: i, bIdx: -1})
+			i++
+		} else {
+			ops = append(ops, diffOp{kind: '+', line: b[j], aIdx: -1, bIdx: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{kind: '-', line: a[i], aIdx: i, bIdx: -1})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{kind: '+', line: b[j], aIdx: -1, bIdx: j})
+	}
+	return ops
+}
@@ -0,0 +1,264 @@
+package hoist
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed settings.schema.json
+var schemaJSON []byte
+
+// Issue is a problem found while validating a settings file.
+type Issue struct {
+	Path  string // JSON path, e.g. permissions.allow[2]
+	Line  int
+	Col   int
+	Error bool // false for warnings
+	Msg   string
+}
+
+func (i Issue) String() string {
+	kind := "warning"
+	if i.Error {
+		kind = "error"
+	}
+	path := i.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%d:%d: %s: %s: %s", i.Line, i.Col, kind, path, i.Msg)
+}
+
+// schema is the subset of JSON Schema the embedded settings schema uses.
+type schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []string           `json:"enum"`
+	Format               string             `json:"format"`
+	Ref                  string             `json:"$ref"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+var settingsSchema = func() *schema {
+	var s schema
+	if err := json.Unmarshal(schemaJSON, &s); err != nil {
+		panic("hoist: invalid embedded schema: " + err.Error())
+	}
+	return &s
+}()
+
+// Validate checks settings file content against the known Claude Code
+// settings keys and value types, and parses every permission rule. Unknown
+// keys are errors when they look like a typo of a known key and warnings
+// otherwise. Content that doesn't parse yields a *SyntaxError.
+func Validate(data []byte) ([]Issue, error) {
+	var v any
+	if err := UnmarshalJSONC(data, &v); err != nil {
+		return nil, err
+	}
+	root, err := parseNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	val := validator{data: data, defs: settingsSchema.Definitions}
+	val.check(root, settingsSchema, "")
+	if val.err != nil {
+		return nil, val.err
+	}
+	sort.SliceStable(val.issues, func(i, j int) bool {
+		a, b := val.issues[i], val.issues[j]
+		return a.Line < b.Line || a.Line == b.Line && a.Col < b.Col
+	})
+	return val.issues, nil
+}
+
+type validator struct {
+	data   []byte
+	defs   map[string]*schema
+	issues []Issue
+	err    error // a problem with the schema itself
+}
+
+func (v *validator) report(offset int, path string, isErr bool, format string, args ...any) {
+	line, col := position(v.data, offset)
+	v.issues = append(v.issues, Issue{Path: path, Line: line, Col: col, Error: isErr, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) check(n *node, s *schema, path string) {
+	if s.Ref != "" {
+		def, ok := v.defs[s.Ref]
+		if !ok {
+			if v.err == nil {
+				v.err = fmt.Errorf("settings schema: %s: unknown $ref %q", path, s.Ref)
+			}
+			return
+		}
+		s = def
+	}
+
+	if !matchesType(n, s.Type) {
+		v.report(n.start, path, true, "expected %s, got %s", s.Type, describeNode(n))
+		return
+	}
+
+	switch n.kind {
+	case '{':
+		v.checkObject(n, s, path)
+	case '[':
+		if s.Items != nil {
+			for i, el := range n.elems {
+				v.check(el, s.Items, path+"["+strconv.Itoa(i)+"]")
+			}
+		}
+	default:
+		str, _ := n.value.(string)
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			v.report(n.start, path, true, "%q is not one of %s", str, strings.Join(s.Enum, ", "))
+		}
+		if s.Format == "rule" {
+			if _, err := ParseRule(str); err != nil {
+				v.report(n.start, path, true, "%v", err)
+			}
+		}
+	}
+}
+
+func (v *validator) checkObject(n *node, s *schema, path string) {
+	var additional *schema
+	closed := false
+	if len(s.AdditionalProperties) > 0 {
+		if string(s.AdditionalProperties) == "false" {
+			closed = true
+		} else {
+			additional = &schema{}
+			json.Unmarshal(s.AdditionalProperties, additional)
+		}
+	}
+
+	known := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+
+	for i, key := range n.keys {
+		child := joinPath(path, key)
+		if prop, ok := s.Properties[key]; ok {
+			v.check(n.elems[i], prop, child)
+			continue
+		}
+		if additional != nil {
+			v.check(n.elems[i], additional, child)
+			continue
+		}
+		if !closed {
+			continue
+		}
+		if guess := suggest(key, known); guess != "" {
+			v.report(n.keyStarts[i], child, true, "unknown key %q, did you mean %q?", key, guess)
+		} else {
+			v.report(n.keyStarts[i], child, false, "unknown key %q", key)
+		}
+	}
+}
+
+func matchesType(n *node, typ string) bool {
+	switch typ {
+	case "":
+		return true
+	case "object":
+		return n.kind == '{'
+	case "array":
+		return n.kind == '['
+	case "string":
+		_, ok := n.value.(string)
+		return ok
+	case "boolean":
+		_, ok := n.value.(bool)
+		return ok
+	case "number":
+		_, ok := n.value.(json.Number)
+		return ok
+	case "integer":
+		num, ok := n.value.(json.Number)
+		return ok && !strings.ContainsAny(string(num), ".eE")
+	}
+	return false
+}
+
+func describeNode(n *node) string {
+	switch n.kind {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	}
+	switch n.value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	}
+	return "null"
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns the known key closest to key when it's close enough to be
+// a likely typo, or "".
+func suggest(key string, known []string) string {
+	best, bestDist := "", -1
+	for _, k := range known {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(k))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	if bestDist < 0 || bestDist > max(1, len(key)/3) {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,83 @@
+package hoist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	src := `{
+  "permisions": {},
+  "permissions": {
+    "alow": ["Read"],
+    "allow": ["Bash(ls:*)", "Bash(", 3],
+    "defaultMode": "yolo"
+  },
+  "cleanupPeriodDays": 1.5,
+  "somethingNew": true,
+  "env": {"A": 1},
+  "hooks": {"PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "x"}]}]}
+}`
+	issues, err := Validate([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		path  string
+		line  int
+		isErr bool
+		msg   string
+	}{
+		{"permisions", 2, true, `did you mean "permissions"`},
+		{"permissions.alow", 4, true, `did you mean "allow"`},
+		{"permissions.allow[1]", 5, true, "missing closing parenthesis"},
+		{"permissions.allow[2]", 5, true, "expected string, got number"},
+		{"permissions.defaultMode", 6, true, "not one of"},
+		{"cleanupPeriodDays", 8, true, "expected integer"},
+		{"somethingNew", 9, false, `unknown key "somethingNew"`},
+		{"env.A", 10, true, "expected string"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d:\n%v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Path != w.path || got.Line != w.line || got.Error != w.isErr || !strings.Contains(got.Msg, w.msg) {
+			t.Errorf("issue %d = %s, want %s at line %d containing %q", i, got, w.path, w.line, w.msg)
+		}
+	}
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	_, err := Validate([]byte("{\n  \"a\": }"))
+	var syn *SyntaxError
+	if !errors.As(err, &syn) || syn.Line != 2 {
+		t.Fatalf("expected syntax error on line 2, got %v", err)
+	}
+}
+
+func TestValidateUnknownRef(t *testing.T) {
+	data := []byte(`{"hooks": {}}`)
+	root, err := parseNode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &schema{Type: "object", Properties: map[string]*schema{"hooks": {Ref: "missing"}}}
+	val := validator{data: data}
+	val.check(root, s, "")
+	if val.err == nil || !strings.Contains(val.err.Error(), `"missing"`) {
+		t.Errorf("got %v", val.err)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	known := []string{"allow", "ask", "deny"}
+	if got := suggest("alow", known); got != "allow" {
+		t.Fatalf("suggest(alow) = %q", got)
+	}
+	if got := suggest("somethingElse", known); got != "" {
+		t.Fatalf("suggest(somethingElse) = %q", got)
+	}
+}